@@ -0,0 +1,175 @@
+package sqllogger
+
+import (
+	"context"
+	"sync"
+)
+
+// OverflowPolicy decides what happens to new operations if the buffer of an AsyncLogger is full
+type OverflowPolicy int
+
+const (
+	// OverflowDrop drops the new operation
+	OverflowDrop OverflowPolicy = iota
+	// OverflowBlock blocks the operation until there is space in the buffer
+	OverflowBlock
+	// OverflowDropOldest drops the oldest buffered operation in favor of the new one
+	OverflowDropOldest
+)
+
+// DefaultAsyncBufferSize is the buffer size of an AsyncLogger if no BufferSize is set
+const DefaultAsyncBufferSize = 1024
+
+// AsyncOpts configures NewAsyncLogger
+type AsyncOpts struct {
+	// BufferSize is the maximum number of buffered operations, DefaultAsyncBufferSize is used if zero
+	BufferSize int
+	// Overflow is the policy for new operations if the buffer is full
+	Overflow OverflowPolicy
+}
+
+// NewAsyncLogger creates a SQLLogger that buffers operations and forwards them to the given logger in a background
+// goroutine, so a slow logger does not add to the latency of queries
+//
+// Events are cloned before they are buffered, the context is retained without its cancellation and with the values of
+// the exec result (see GetExecResult). Close must be called
+// to stop the background goroutine after all operations are forwarded.
+func NewAsyncLogger(log SQLLogger, opts AsyncOpts) *AsyncLogger {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = DefaultAsyncBufferSize
+	}
+	a := &AsyncLogger{
+		log:    log,
+		opts:   opts,
+		buffer: make([]asyncEntry, opts.BufferSize),
+		done:   make(chan struct{}),
+	}
+	a.cond.L = &a.mx
+	a.eventSQLLogger.l = a
+	go a.run()
+	return a
+}
+
+// AsyncLogger is a SQLLogger forwarding operations asynchronously to another logger, it is safe for concurrent use
+type AsyncLogger struct {
+	eventSQLLogger
+
+	log  SQLLogger
+	opts AsyncOpts
+
+	mx   sync.Mutex
+	cond sync.Cond
+	// buffer is a ring buffer of size entries starting at head
+	buffer     []asyncEntry
+	head       int
+	size       int
+	processing bool
+	closed     bool
+	dropped    int64
+
+	done chan struct{}
+}
+
+var _ SQLLogger = &AsyncLogger{}
+var _ SQLErrorLogger = &AsyncLogger{}
+
+type asyncEntry struct {
+	ctx context.Context
+	ev  Event
+}
+
+// LogEvent satisfies EventLogger interface
+func (a *AsyncLogger) LogEvent(ctx context.Context, ev Event) {
+	// The driver result must not be used after the operation returned, so its values are read before buffering
+	if result, ok := GetExecResult(ctx); ok {
+		ctx = WithExecResult(ctx, result)
+	}
+	entry := asyncEntry{ctx: context.WithoutCancel(ctx), ev: ev.Clone()}
+
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	if a.opts.Overflow == OverflowBlock {
+		for a.size == len(a.buffer) && !a.closed {
+			a.cond.Wait()
+		}
+	}
+	if a.closed {
+		a.dropped++
+		return
+	}
+	if a.size == len(a.buffer) {
+		a.dropped++
+		if a.opts.Overflow != OverflowDropOldest {
+			return
+		}
+		a.pop()
+	}
+
+	a.buffer[(a.head+a.size)%len(a.buffer)] = entry
+	a.size++
+	a.cond.Broadcast()
+}
+
+// pop removes the oldest entry from the buffer, the lock must be held
+func (a *AsyncLogger) pop() asyncEntry {
+	entry := a.buffer[a.head]
+	a.buffer[a.head] = asyncEntry{}
+	a.head = (a.head + 1) % len(a.buffer)
+	a.size--
+	return entry
+}
+
+func (a *AsyncLogger) run() {
+	defer close(a.done)
+
+	a.mx.Lock()
+	for {
+		for a.size == 0 && !a.closed {
+			a.cond.Wait()
+		}
+		if a.size == 0 {
+			a.mx.Unlock()
+			return
+		}
+		entry := a.pop()
+		a.processing = true
+		a.cond.Broadcast()
+		a.mx.Unlock()
+
+		entry.ev.Dispatch(entry.ctx, a.log)
+
+		a.mx.Lock()
+		a.processing = false
+		a.cond.Broadcast()
+	}
+}
+
+// Flush blocks until all buffered operations are forwarded to the wrapped logger
+func (a *AsyncLogger) Flush() {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	for a.size > 0 || a.processing {
+		a.cond.Wait()
+	}
+}
+
+// Close forwards all buffered operations and stops the background goroutine, operations logged after Close are dropped
+func (a *AsyncLogger) Close() error {
+	a.mx.Lock()
+	a.closed = true
+	a.cond.Broadcast()
+	a.mx.Unlock()
+
+	<-a.done
+	return nil
+}
+
+// Dropped returns the number of operations that were dropped because the buffer was full or the logger was closed
+func (a *AsyncLogger) Dropped() int64 {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	return a.dropped
+}
@@ -0,0 +1,71 @@
+package sqllogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+)
+
+type blockingLogger struct {
+	testLogger
+	started chan struct{}
+	release chan struct{}
+}
+
+func (bl *blockingLogger) Printf(format string, args ...interface{}) {
+	bl.started <- struct{}{}
+	<-bl.release
+	bl.testLogger.Printf(format, args...)
+}
+
+func TestAsyncLogger_ClonesArgs(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	defaultSQLLogger.InterpolateArgs = true
+	a := NewAsyncLogger(defaultSQLLogger, AsyncOpts{})
+	defer a.Close()
+
+	arg := []byte("abc")
+	a.StmtExec(context.Background(), 1, "INSERT INTO t (b) VALUES (?)", []driver.Value{arg})
+	copy(arg, "xyz")
+	a.Flush()
+
+	expectedEntry := "STMT(1) ► Exec(INSERT INTO t (b) VALUES (X'616263'))"
+	if len(l) != 1 || l[0] != expectedEntry {
+		t.Errorf("expected log entry %q, but got %q", expectedEntry, l)
+	}
+}
+
+func TestAsyncLogger_OverflowDropOldest(t *testing.T) {
+	bl := &blockingLogger{started: make(chan struct{}), release: make(chan struct{})}
+	a := NewAsyncLogger(NewDefaultSQLLogger(bl), AsyncOpts{BufferSize: 2, Overflow: OverflowDropOldest})
+
+	ctx := context.Background()
+	a.TxCommit(ctx, 1)
+	// Wait until the first operation is processed, so the buffer is empty
+	<-bl.started
+	a.TxCommit(ctx, 2)
+	a.TxCommit(ctx, 3)
+	a.TxCommit(ctx, 4)
+
+	go func() {
+		for range bl.started {
+			bl.release <- struct{}{}
+		}
+	}()
+	bl.release <- struct{}{}
+	_ = a.Close()
+	close(bl.started)
+
+	a.TxCommit(ctx, 5)
+
+	expectedEntries := "[  TX(1) ► Commit   TX(3) ► Commit   TX(4) ► Commit]"
+	if actual := fmt.Sprint(bl.testLogger); actual != expectedEntries {
+		t.Errorf("expected log entries %q, but got %q", expectedEntries, actual)
+	}
+	if dropped := a.Dropped(); dropped != 2 {
+		t.Errorf("expected 2 dropped operations, got %d", dropped)
+	}
+}
@@ -0,0 +1,81 @@
+package sqllogger
+
+import (
+	"context"
+	"sync"
+)
+
+// Collect attaches a new Collector to the context that records every query and exec operation executed with the
+// returned context through a LoggingConnector
+//
+// This works independently of the SQLLogger passed to LoggingConnector and can be used to show the statements of a
+// request on a debug page or to assert the executed statements in tests.
+func Collect(ctx context.Context) (context.Context, *Collector) {
+	c := &Collector{}
+	return context.WithValue(ctx, collectorKey{}, c), c
+}
+
+// Collector records statements executed with a context, it is safe for concurrent use
+type Collector struct {
+	mx         sync.Mutex
+	statements []CollectedStatement
+}
+
+// CollectedStatement is a query or exec operation recorded by a Collector
+type CollectedStatement struct {
+	Event
+	Timing  Timing
+	Lineage Lineage
+}
+
+type collectorKey struct{}
+
+// collect records the event if a Collector is attached to the context
+//
+// Only operations with a context (e.g. ConnQueryContext, StmtExecContext) are recorded, since other operations cannot
+// be associated with a Collector.
+func collect(ctx context.Context, ev Event) {
+	c, ok := ctx.Value(collectorKey{}).(*Collector)
+	if !ok {
+		return
+	}
+	kind := ev.Op.Kind()
+	if kind != KindQuery && kind != KindExec {
+		return
+	}
+	timing, _ := GetTiming(ctx)
+	lineage, _ := GetLineage(ctx)
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.statements = append(c.statements, CollectedStatement{
+		Event:   ev.Clone(),
+		Timing:  timing,
+		Lineage: lineage,
+	})
+}
+
+// Statements returns a copy of the recorded statements in the order of execution
+func (c *Collector) Statements() []CollectedStatement {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return append([]CollectedStatement(nil), c.statements...)
+}
+
+// Count returns the number of recorded statements
+func (c *Collector) Count() int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return len(c.statements)
+}
+
+// Reset removes all recorded statements
+func (c *Collector) Reset() {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.statements = nil
+}
@@ -0,0 +1,60 @@
+package sqllogger_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/networkteam/go-sqllogger"
+)
+
+func TestCollect(t *testing.T) {
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(newTestLogger(), connector)
+
+	db := sql.OpenDB(loggingConnector)
+	defer db.Close()
+
+	_, err := db.ExecContext(context.Background(), "CREATE|collect|id=int64")
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+
+	ctx, collector := sqllogger.Collect(context.Background())
+
+	_, err = db.ExecContext(ctx, "INSERT|collect|id=?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT|collect|id|")
+	if err != nil {
+		t.Fatalf("Unexpected error from QueryContext: %v", err)
+	}
+	_ = rows.Close()
+	_, err = db.ExecContext(ctx, "INSERT|unknown|id=?", 2)
+	if err == nil {
+		t.Fatalf("Expected error from ExecContext")
+	}
+	_, err = db.ExecContext(context.Background(), "INSERT|collect|id=?", 3)
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+
+	if collector.Count() != 2 {
+		t.Fatalf("Expected 2 collected statements, got %d: %+v", collector.Count(), collector.Statements())
+	}
+
+	statements := collector.Statements()
+	if statements[0].Op != sqllogger.OpStmtExecContext || statements[0].Query != "INSERT|collect|id=?" {
+		t.Errorf("Expected first statement to be insert, got %+v", statements[0])
+	}
+	if len(statements[0].NamedArgs) != 1 || statements[0].NamedArgs[0].Value != int64(1) {
+		t.Errorf("Expected args of insert to be collected, got %+v", statements[0].NamedArgs)
+	}
+	if statements[0].Timing.Start.IsZero() {
+		t.Errorf("Expected timing of insert to be collected")
+	}
+	if statements[1].Op != sqllogger.OpStmtQueryContext || statements[1].RowsID == 0 {
+		t.Errorf("Expected second statement to be query with rows id, got %+v", statements[1])
+	}
+}
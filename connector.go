@@ -7,7 +7,6 @@ import (
 	"errors"
 	"io"
 	"reflect"
-	"sync"
 	"time"
 )
 
@@ -16,49 +15,72 @@ import (
 //
 // Note: Due to the amount of optional interfaces in the database/sql/driver package, there might be some features
 // of the original driver that are not exposed on the returned driver.Connector.
-func LoggingConnector(log SQLLogger, connector driver.Connector) driver.Connector {
+func LoggingConnector(log SQLLogger, connector driver.Connector, opts ...Option) driver.Connector {
+	o := connectorOpts{
+		ids:       defaultIDGenerator,
+		now:       time.Now,
+		args:      true,
+		logErrors: true,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
 	return &lconnector{
-		log:  log,
+		log:  o.wrap(log),
 		cnct: connector,
+		ids:  o.ids,
+		now:  o.now,
+		db:   o.db,
+		args: o.args,
 	}
 }
 
-var (
-	idseq   int64
-	idseqMx sync.Mutex
-)
-
 type lconnector struct {
 	cnct driver.Connector
 	log  SQLLogger
+	ids  IDGenerator
+	now  func() time.Time
+	db   DatabaseInfo
+	args bool
+}
+
+// context adds the values of the connector and the lineage of an operation to the context
+func (l *lconnector) context(ctx context.Context, lineage Lineage) context.Context {
+	if l.db != (DatabaseInfo{}) {
+		ctx = WithDatabaseInfo(ctx, l.db)
+	}
+	return WithLineage(ctx, lineage)
 }
 
 var _ driver.Connector = &lconnector{}
 
 func (l *lconnector) Connect(ctx context.Context) (driver.Conn, error) {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.now()}
 	originalConn, err := l.cnct.Connect(ctx)
+	timing.End = l.now()
+	ctx = WithTiming(ctx, timing)
 	if err != nil {
+		l.logError(l.context(ctx, Lineage{}), Event{Op: OpConnect, Err: err})
 		return nil, err
 	}
 
-	timing.End = time.Now()
-	ctx = WithTiming(ctx, timing)
-
-	id := nextID()
+	id := l.ids.NextID(IDConn)
+	ctx = l.context(ctx, Lineage{ConnID: id})
 	l.log.Connect(ctx, id)
-	return &lconn{id: id, log: l.log, conn: originalConn}, nil
+	return &lconn{id: id, c: l, conn: originalConn}, nil
 }
 
 func (l *lconnector) Driver() driver.Driver {
 	origDriver := l.cnct.Driver()
-	return &ld{log: l.log, drv: origDriver}
+	return &ld{c: l, drv: origDriver}
 }
 
 type lconn struct {
 	id   int64
-	log  SQLLogger
+	c    *lconnector
 	conn driver.Conn
+	// txID is the ID of the active transaction, database/sql serializes all calls on a connection
+	txID int64
 }
 
 var _ driver.Conn = &lconn{}
@@ -74,34 +96,36 @@ var _ driver.Pinger = &lconn{}
 var _ driver.Validator = &lconn{}
 
 func (l *lconn) Begin() (driver.Tx, error) {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	origTx, err := l.conn.Begin()
+	timing.End = l.c.now()
+	ctx := WithTiming(context.Background(), timing)
+	ctx = l.c.context(ctx, l.lineage())
 	if err != nil {
+		l.c.logError(ctx, Event{Op: OpConnBegin, ConnID: l.id, Err: err})
 		return nil, err
 	}
 
-	timing.End = time.Now()
-	ctx := WithTiming(context.Background(), timing)
-
-	txID := nextID()
-	l.log.ConnBegin(ctx, l.id, txID, driver.TxOptions{})
+	txID := l.c.ids.NextID(IDTx)
+	l.c.log.ConnBegin(ctx, l.id, txID, driver.TxOptions{})
 
 	return l.wrapTx(txID, origTx), nil
 }
 
 func (l *lconn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
 	if connBeginTx, ok := l.conn.(driver.ConnBeginTx); ok {
-		timing := Timing{Start: time.Now()}
+		timing := Timing{Start: l.c.now()}
 		origTx, err := connBeginTx.BeginTx(ctx, opts)
+		timing.End = l.c.now()
+		ctx = WithTiming(ctx, timing)
+		ctx = l.c.context(ctx, l.lineage())
 		if err != nil {
+			l.c.logError(ctx, Event{Op: OpConnBegin, ConnID: l.id, TxOptions: opts, Err: err})
 			return nil, err
 		}
 
-		timing.End = time.Now()
-		ctx = WithTiming(ctx, timing)
-
-		txID := nextID()
-		l.log.ConnBegin(ctx, l.id, txID, opts)
+		txID := l.c.ids.NextID(IDTx)
+		l.c.log.ConnBegin(ctx, l.id, txID, opts)
 
 		return l.wrapTx(txID, origTx), nil
 	}
@@ -120,67 +144,77 @@ func (l *lconn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx,
 		return nil, errors.New("sql: driver does not support read-only transactions")
 	}
 
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	origTx, err := l.conn.Begin()
+	timing.End = l.c.now()
+	ctx = WithTiming(ctx, timing)
+	ctx = l.c.context(ctx, l.lineage())
 	if err != nil {
+		l.c.logError(ctx, Event{Op: OpConnBegin, ConnID: l.id, TxOptions: opts, Err: err})
 		return nil, err
 	}
 
-	timing.End = time.Now()
-	ctx = WithTiming(ctx, timing)
-
-	txID := nextID()
-	l.log.ConnBegin(ctx, l.id, txID, opts)
+	txID := l.c.ids.NextID(IDTx)
+	l.c.log.ConnBegin(ctx, l.id, txID, opts)
 
 	return l.wrapTx(txID, origTx), nil
 }
 
 func (l *lconn) Query(query string, args []driver.Value) (driver.Rows, error) {
 	if queryer, ok := l.conn.(driver.Queryer); ok {
-		timing := Timing{Start: time.Now()}
+		timing := Timing{Start: l.c.now()}
 		origRows, err := queryer.Query(query, args)
+		timing.End = l.c.now()
+		ctx := WithTiming(context.Background(), timing)
+		ctx = l.c.context(ctx, l.lineage())
 		if err != nil {
+			l.c.logError(ctx, Event{Op: OpConnQuery, ConnID: l.id, Query: query, Args: args, Err: err})
 			return nil, err
 		}
 
-		timing.End = time.Now()
-		ctx := WithTiming(context.Background(), timing)
-
-		rowsID := nextID()
-		l.log.ConnQuery(ctx, l.id, rowsID, query, args)
+		rowsID := l.c.ids.NextID(IDRows)
+		l.c.log.ConnQuery(ctx, l.id, rowsID, query, args)
 
-		return wrapRows(rowsID, l.log, origRows), nil
+		return wrapRows(rowsID, l.c, origRows, timing.Start, l.lineage()), nil
 	}
 	return nil, driver.ErrSkip
 }
 
 func (l *lconn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
 	if queryerCtx, ok := l.conn.(driver.QueryerContext); ok {
+		timing := Timing{Start: l.c.now()}
 		origRows, err := queryerCtx.QueryContext(ctx, query, args)
+		timing.End = l.c.now()
+		ctx = WithTiming(ctx, timing)
+		ctx = l.c.context(ctx, l.lineage())
 		if err != nil {
+			l.c.logError(ctx, Event{Op: OpConnQueryContext, ConnID: l.id, Query: query, NamedArgs: args, Err: err})
 			return nil, err
 		}
 
-		rowsID := nextID()
-		l.log.ConnQueryContext(ctx, l.id, rowsID, query, args)
+		rowsID := l.c.ids.NextID(IDRows)
+		l.c.log.ConnQueryContext(ctx, l.id, rowsID, query, args)
+		l.c.collect(ctx, Event{Op: OpConnQueryContext, ConnID: l.id, RowsID: rowsID, Query: query, NamedArgs: args})
 
-		return wrapRows(rowsID, l.log, origRows), nil
+		return wrapRows(rowsID, l.c, origRows, timing.Start, l.lineage()), nil
 	}
 	return nil, driver.ErrSkip
 }
 
 func (l *lconn) Exec(query string, args []driver.Value) (driver.Result, error) {
 	if execer, ok := l.conn.(driver.Execer); ok {
-		timing := Timing{Start: time.Now()}
+		timing := Timing{Start: l.c.now()}
 		res, err := execer.Exec(query, args)
+		timing.End = l.c.now()
+		ctx := WithTiming(context.Background(), timing)
+		ctx = l.c.context(ctx, l.lineage())
 		if err != nil {
+			l.c.logError(ctx, Event{Op: OpConnExec, ConnID: l.id, Query: query, Args: args, Err: err})
 			return nil, err
 		}
 
-		timing.End = time.Now()
-		ctx := WithTiming(context.Background(), timing)
-
-		l.log.ConnExec(ctx, l.id, query, args)
+		ctx = withDriverResult(ctx, res)
+		l.c.log.ConnExec(ctx, l.id, query, args)
 
 		return res, nil
 	}
@@ -189,16 +223,19 @@ func (l *lconn) Exec(query string, args []driver.Value) (driver.Result, error) {
 
 func (l *lconn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
 	if execerCtx, ok := l.conn.(driver.ExecerContext); ok {
-		timing := Timing{Start: time.Now()}
+		timing := Timing{Start: l.c.now()}
 		res, err := execerCtx.ExecContext(ctx, query, args)
+		timing.End = l.c.now()
+		ctx = WithTiming(ctx, timing)
+		ctx = l.c.context(ctx, l.lineage())
 		if err != nil {
+			l.c.logError(ctx, Event{Op: OpConnExecContext, ConnID: l.id, Query: query, NamedArgs: args, Err: err})
 			return nil, err
 		}
 
-		timing.End = time.Now()
-		ctx = WithTiming(ctx, timing)
-
-		l.log.ConnExecContext(ctx, l.id, query, args)
+		ctx = withDriverResult(ctx, res)
+		l.c.log.ConnExecContext(ctx, l.id, query, args)
+		l.c.collect(ctx, Event{Op: OpConnExecContext, ConnID: l.id, Query: query, NamedArgs: args})
 
 		return res, nil
 	}
@@ -206,36 +243,38 @@ func (l *lconn) ExecContext(ctx context.Context, query string, args []driver.Nam
 }
 
 func (l *lconn) Prepare(query string) (driver.Stmt, error) {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	origStmt, err := l.conn.Prepare(query)
+	timing.End = l.c.now()
+	ctx := WithTiming(context.Background(), timing)
+	ctx = l.c.context(ctx, l.lineage())
 	if err != nil {
+		l.c.logError(ctx, Event{Op: OpConnPrepare, ConnID: l.id, Query: query, Err: err})
 		return nil, err
 	}
 
-	timing.End = time.Now()
-	ctx := WithTiming(context.Background(), timing)
-
-	stmtID := nextID()
-	l.log.ConnPrepare(ctx, l.id, stmtID, query)
+	stmtID := l.c.ids.NextID(IDStmt)
+	l.c.log.ConnPrepare(ctx, l.id, stmtID, query)
 
-	return &lstmt{id: stmtID, log: l.log, stmt: origStmt, query: query}, nil
+	return &lstmt{id: stmtID, c: l.c, conn: l, stmt: origStmt, query: query}, nil
 }
 
 func (l *lconn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
 	if connPrepareCtx, ok := l.conn.(driver.ConnPrepareContext); ok {
-		timing := Timing{Start: time.Now()}
+		timing := Timing{Start: l.c.now()}
 		origStmt, err := connPrepareCtx.PrepareContext(ctx, query)
+		timing.End = l.c.now()
+		ctx = WithTiming(ctx, timing)
+		ctx = l.c.context(ctx, l.lineage())
 		if err != nil {
+			l.c.logError(ctx, Event{Op: OpConnPrepareContext, ConnID: l.id, Query: query, Err: err})
 			return nil, err
 		}
 
-		timing.End = time.Now()
-		ctx = WithTiming(ctx, timing)
-
-		stmtID := nextID()
-		l.log.ConnPrepareContext(ctx, l.id, stmtID, query)
+		stmtID := l.c.ids.NextID(IDStmt)
+		l.c.log.ConnPrepareContext(ctx, l.id, stmtID, query)
 
-		return &lstmt{id: stmtID, log: l.log, stmt: origStmt, query: query}, nil
+		return &lstmt{id: stmtID, c: l.c, conn: l, stmt: origStmt, query: query}, nil
 	}
 
 	// Copied from ctxutil.go to handle fallback if interface is not implemented
@@ -280,14 +319,22 @@ func (l *lconn) IsValid() bool {
 	return true // Default to assuming it's valid
 }
 
+func (l *lconn) lineage() Lineage {
+	return Lineage{ConnID: l.id, TxID: l.txID}
+}
+
 func (l *lconn) Close() error {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	err := l.conn.Close()
 
-	timing.End = time.Now()
+	timing.End = l.c.now()
 	ctx := WithTiming(context.Background(), timing)
+	ctx = l.c.context(ctx, l.lineage())
 
-	l.log.ConnClose(ctx, l.id)
+	l.c.log.ConnClose(ctx, l.id)
+	if err != nil {
+		l.c.logError(ctx, Event{Op: OpConnClose, ConnID: l.id, Err: err})
+	}
 
 	return err
 }
@@ -300,7 +347,8 @@ var _ driver.ConnBeginTx = &lconn{}
 var _ driver.ConnPrepareContext = &lconn{}
 
 type lstmt struct {
-	log   SQLLogger
+	c     *lconnector
+	conn  *lconn
 	stmt  driver.Stmt
 	query string
 	id    int64
@@ -312,48 +360,67 @@ var _ driver.StmtQueryContext = &lstmt{}
 var _ driver.NamedValueChecker = &lstmt{}
 
 func (l *lstmt) Close() error {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	err := l.stmt.Close()
 
-	timing.End = time.Now()
+	timing.End = l.c.now()
 	ctx := WithTiming(context.Background(), timing)
+	ctx = l.c.context(ctx, l.lineage())
 
-	l.log.StmtClose(ctx, l.id)
+	l.c.log.StmtClose(ctx, l.id)
+	if err != nil {
+		l.c.logError(ctx, Event{Op: OpStmtClose, StmtID: l.id, Err: err})
+	}
 
 	return err
 }
 
+func (l *lstmt) lineage() Lineage {
+	return l.conn.lineage()
+}
+
+func (l *lstmt) rowsLineage() Lineage {
+	lineage := l.conn.lineage()
+	lineage.StmtID = l.id
+	return lineage
+}
+
 func (l *lstmt) NumInput() int {
 	return l.stmt.NumInput()
 }
 
 func (l *lstmt) Exec(args []driver.Value) (driver.Result, error) {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	res, err := l.stmt.Exec(args)
+	timing.End = l.c.now()
+	ctx := WithTiming(context.Background(), timing)
+	ctx = l.c.context(ctx, l.lineage())
 	if err != nil {
+		l.c.logError(ctx, Event{Op: OpStmtExec, StmtID: l.id, Query: l.query, Args: args, Err: err})
 		return nil, err
 	}
 
-	timing.End = time.Now()
-	ctx := WithTiming(context.Background(), timing)
-
-	l.log.StmtExec(ctx, l.id, l.query, args)
+	ctx = withDriverResult(ctx, res)
+	l.c.log.StmtExec(ctx, l.id, l.query, args)
 
 	return res, err
 }
 
 func (l *lstmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
 	if stmtExecCtx, ok := l.stmt.(driver.StmtExecContext); ok {
-		timing := Timing{Start: time.Now()}
+		timing := Timing{Start: l.c.now()}
 		res, err := stmtExecCtx.ExecContext(ctx, args)
+		timing.End = l.c.now()
+		ctx = WithTiming(ctx, timing)
+		ctx = l.c.context(ctx, l.lineage())
 		if err != nil {
+			l.c.logError(ctx, Event{Op: OpStmtExecContext, StmtID: l.id, Query: l.query, NamedArgs: args, Err: err})
 			return nil, err
 		}
 
-		timing.End = time.Now()
-		ctx = WithTiming(ctx, timing)
-
-		l.log.StmtExecContext(ctx, l.id, l.query, args)
+		ctx = withDriverResult(ctx, res)
+		l.c.log.StmtExecContext(ctx, l.id, l.query, args)
+		l.c.collect(ctx, Event{Op: OpStmtExecContext, StmtID: l.id, Query: l.query, NamedArgs: args})
 
 		return res, nil
 	}
@@ -374,36 +441,39 @@ func (l *lstmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driv
 }
 
 func (l *lstmt) Query(args []driver.Value) (driver.Rows, error) {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	origRows, err := l.stmt.Query(args)
+	timing.End = l.c.now()
+	ctx := WithTiming(context.Background(), timing)
+	ctx = l.c.context(ctx, l.lineage())
 	if err != nil {
+		l.c.logError(ctx, Event{Op: OpStmtQuery, StmtID: l.id, Query: l.query, Args: args, Err: err})
 		return nil, err
 	}
 
-	timing.End = time.Now()
-	ctx := WithTiming(context.Background(), timing)
-
-	rowsID := nextID()
-	l.log.StmtQuery(ctx, l.id, rowsID, l.query, args)
+	rowsID := l.c.ids.NextID(IDRows)
+	l.c.log.StmtQuery(ctx, l.id, rowsID, l.query, args)
 
-	return wrapRows(rowsID, l.log, origRows), nil
+	return wrapRows(rowsID, l.c, origRows, timing.Start, l.rowsLineage()), nil
 }
 
 func (l *lstmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
 	if stmtQueryCtx, ok := l.stmt.(driver.StmtQueryContext); ok {
-		timing := Timing{Start: time.Now()}
+		timing := Timing{Start: l.c.now()}
 		rows, err := stmtQueryCtx.QueryContext(ctx, args)
+		timing.End = l.c.now()
+		ctx = WithTiming(ctx, timing)
+		ctx = l.c.context(ctx, l.lineage())
 		if err != nil {
+			l.c.logError(ctx, Event{Op: OpStmtQueryContext, StmtID: l.id, Query: l.query, NamedArgs: args, Err: err})
 			return nil, err
 		}
 
-		timing.End = time.Now()
-		ctx = WithTiming(ctx, timing)
-
-		rowsID := nextID()
-		l.log.StmtQueryContext(ctx, l.id, rowsID, l.query, args)
+		rowsID := l.c.ids.NextID(IDRows)
+		l.c.log.StmtQueryContext(ctx, l.id, rowsID, l.query, args)
+		l.c.collect(ctx, Event{Op: OpStmtQueryContext, StmtID: l.id, RowsID: rowsID, Query: l.query, NamedArgs: args})
 
-		return wrapRows(rowsID, l.log, rows), nil
+		return wrapRows(rowsID, l.c, rows, timing.Start, l.rowsLineage()), nil
 	}
 
 	// Copied from ctxutil.go for fallback handling if driver does not implement StmtQueryContext
@@ -432,9 +502,14 @@ var _ driver.StmtExecContext = &lstmt{}
 var _ driver.StmtQueryContext = &lstmt{}
 
 type lrows struct {
-	log  SQLLogger
-	rows driver.Rows
-	id   int64
+	c       *lconnector
+	rows    driver.Rows
+	id      int64
+	parents Lineage
+
+	start time.Time
+	count int64
+	eof   bool
 }
 
 var _ driver.Rows = &lrows{}
@@ -454,7 +529,11 @@ func (l *lrows) HasNextResultSet() bool {
 
 func (l *lrows) NextResultSet() error {
 	if nrsRows, ok := l.rows.(driver.RowsNextResultSet); ok {
-		return nrsRows.NextResultSet()
+		err := nrsRows.NextResultSet()
+		if err == nil {
+			l.eof = false
+		}
+		return err
 	}
 	return io.EOF
 }
@@ -464,19 +543,36 @@ func (l *lrows) Columns() []string {
 }
 
 func (l *lrows) Close() error {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	err := l.rows.Close()
 
-	timing.End = time.Now()
+	timing.End = l.c.now()
 	ctx := WithTiming(context.Background(), timing)
-
-	l.log.RowsClose(ctx, l.id)
+	ctx = l.c.context(ctx, l.lineage())
+	ctx = WithRowsStats(ctx, RowsStats{
+		Rows:   l.count,
+		Timing: Timing{Start: l.start, End: timing.End},
+		EOF:    l.eof,
+		Err:    err,
+	})
+
+	l.c.log.RowsClose(ctx, l.id)
+	if err != nil {
+		l.c.logError(ctx, Event{Op: OpRowsClose, RowsID: l.id, Err: err})
+	}
 
 	return err
 }
 
 func (l *lrows) Next(dest []driver.Value) error {
-	return l.rows.Next(dest)
+	err := l.rows.Next(dest)
+	switch err {
+	case nil:
+		l.count++
+	case io.EOF:
+		l.eof = true
+	}
+	return err
 }
 
 func (l *lrows) ColumnTypeDatabaseTypeName(index int) string {
@@ -514,89 +610,143 @@ func (l *lrows) ColumnTypeScanType(index int) reflect.Type {
 	return nil
 }
 
-func wrapRows(id int64, log SQLLogger, rows driver.Rows) driver.Rows {
+func wrapRows(id int64, c *lconnector, rows driver.Rows, start time.Time, lineage Lineage) driver.Rows {
 	return &lrows{
-		id:   id,
-		log:  log,
-		rows: rows,
+		id:      id,
+		c:       c,
+		rows:    rows,
+		start:   start,
+		parents: lineage,
 	}
 }
 
+func (l *lrows) lineage() Lineage {
+	return l.parents
+}
+
 type ltx struct {
-	log SQLLogger
-	tx  driver.Tx
-	id  int64
+	c    *lconnector
+	conn *lconn
+	tx   driver.Tx
+	id   int64
 }
 
 var _ driver.Tx = &ltx{}
 
+func (l *ltx) lineage() Lineage {
+	return Lineage{ConnID: l.conn.id}
+}
+
 func (l *ltx) Commit() error {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	err := l.tx.Commit()
+	l.conn.txID = 0
+	timing.End = l.c.now()
+	ctx := WithTiming(context.Background(), timing)
+	ctx = l.c.context(ctx, l.lineage())
 	if err != nil {
+		l.c.logError(ctx, Event{Op: OpTxCommit, TxID: l.id, Err: err})
 		return err
 	}
 
-	timing.End = time.Now()
-	ctx := WithTiming(context.Background(), timing)
-
-	l.log.TxCommit(ctx, l.id)
+	l.c.log.TxCommit(ctx, l.id)
 
 	return nil
 }
 
 func (l *ltx) Rollback() error {
-	timing := Timing{Start: time.Now()}
+	timing := Timing{Start: l.c.now()}
 	err := l.tx.Rollback()
+	l.conn.txID = 0
+	timing.End = l.c.now()
+	ctx := WithTiming(context.Background(), timing)
+	ctx = l.c.context(ctx, l.lineage())
 	if err != nil {
+		l.c.logError(ctx, Event{Op: OpTxRollback, TxID: l.id, Err: err})
 		return err
 	}
 
-	timing.End = time.Now()
-	ctx := WithTiming(context.Background(), timing)
-
-	l.log.TxRollback(ctx, l.id)
+	l.c.log.TxRollback(ctx, l.id)
 
 	return nil
 }
 
 func (l *lconn) wrapTx(id int64, tx driver.Tx) driver.Tx {
+	l.txID = id
 	return &ltx{
-		id:  id,
-		log: l.log,
-		tx:  tx,
+		id:   id,
+		c:    l.c,
+		conn: l,
+		tx:   tx,
 	}
 }
 
 type ld struct {
-	log SQLLogger
+	c   *lconnector
 	drv driver.Driver
 }
 
 var _ driver.Driver = &ld{}
 var _ driver.DriverContext = &ld{}
 
+// Open opens a connection with the wrapped driver and logs it like a connection of the connector
 func (l *ld) Open(name string) (driver.Conn, error) {
-	panic("Not implemented, use sql.OpenDB(...)")
+	return l.connector(dsnConnector{dsn: name, drv: l.drv}).Connect(context.Background())
 }
 
+// OpenConnector wraps the connector of the wrapped driver or falls back to a connector calling Open with the DSN
 func (l *ld) OpenConnector(name string) (driver.Connector, error) {
 	if dc, ok := l.drv.(driver.DriverContext); ok {
 		connector, err := dc.OpenConnector(name)
 		if err != nil {
 			return nil, err
 		}
-		return LoggingConnector(l.log, connector), nil
+		return l.connector(connector), nil
 	}
-	return nil, driver.ErrSkip
+	return l.connector(dsnConnector{dsn: name, drv: l.drv}), nil
+}
+
+// connector returns a logging connector with the same options as the connector of the driver
+//
+// The DSN is not parsed, the host of the DatabaseInfo is only set if configured with WithDatabase.
+func (l *ld) connector(connector driver.Connector) *lconnector {
+	c := *l.c
+	c.cnct = connector
+	return &c
+}
+
+// dsnConnector is a connector for drivers that do not implement driver.DriverContext, like in database/sql
+type dsnConnector struct {
+	dsn string
+	drv driver.Driver
+}
+
+func (t dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return t.drv.Open(t.dsn)
 }
 
-func nextID() int64 {
-	idseqMx.Lock()
-	defer idseqMx.Unlock()
+func (t dsnConnector) Driver() driver.Driver {
+	return t.drv
+}
+
+// logError reports a failed operation if the logger implements SQLErrorLogger and to a Collector in the context
+func (l *lconnector) logError(ctx context.Context, ev Event) {
+	if ev.Err == driver.ErrSkip {
+		return
+	}
+	l.collect(ctx, ev)
+	if errLog, ok := l.log.(SQLErrorLogger); ok {
+		errLog.OperationError(ctx, ev)
+	}
+}
 
-	idseq++
-	return idseq
+// collect records the operation to a Collector in the context, arguments are omitted if disabled with WithArgs
+func (l *lconnector) collect(ctx context.Context, ev Event) {
+	if !l.args {
+		ev.Args = nil
+		ev.NamedArgs = nil
+	}
+	collect(ctx, ev)
 }
 
 func namedValueToValue(named []driver.NamedValue) ([]driver.Value, error) {
@@ -56,11 +56,363 @@ func TestLoggingConnector(t *testing.T) {
 	}
 }
 
+func TestLoggingConnector_OperationError(t *testing.T) {
+	logger := newTestLogger()
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(logger, connector)
+
+	ctx := context.Background()
+
+	db := sql.OpenDB(loggingConnector)
+	_, err := db.PrepareContext(ctx, "UNKNOWN|fizzbuzz")
+	if err == nil {
+		t.Fatalf("Expected error from PrepareContext")
+	}
+
+	expectedLogs := []string{
+		`Connect`,
+		`OperationError(ConnPrepareContext)`,
+	}
+	if len(logger.logs) != len(expectedLogs) {
+		t.Fatalf("Expected %d log entries, got %d: %+v", len(expectedLogs), len(logger.logs), logger.logs)
+	}
+	for i, actualEntry := range logger.logs {
+		if actualEntry != expectedLogs[i] {
+			t.Errorf("Expected log entry %d to be %q, got %q", i, expectedLogs[i], actualEntry)
+		}
+	}
+
+	if len(logger.errors) != 1 {
+		t.Fatalf("Expected 1 error event, got %d", len(logger.errors))
+	}
+	ev := logger.errors[0]
+	if ev.Query != "UNKNOWN|fizzbuzz" {
+		t.Errorf("Expected query of error event to be %q, got %q", "UNKNOWN|fizzbuzz", ev.Query)
+	}
+	if ev.ConnID == 0 {
+		t.Errorf("Expected connection id of error event to be set")
+	}
+	if ev.Err == nil {
+		t.Errorf("Expected error of error event to be set")
+	}
+}
+
+func TestLoggingConnector_RowsStats(t *testing.T) {
+	logger := newTestLogger()
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(logger, connector)
+
+	ctx := context.Background()
+
+	db := sql.OpenDB(loggingConnector)
+	_, err := db.ExecContext(ctx, "CREATE|rowsstats|id=int64")
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		_, err = db.ExecContext(ctx, "INSERT|rowsstats|id=?", i)
+		if err != nil {
+			t.Fatalf("Unexpected error from ExecContext: %v", err)
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT|rowsstats|id|")
+	if err != nil {
+		t.Fatalf("Unexpected error from QueryContext: %v", err)
+	}
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Unexpected error from rows: %v", err)
+	}
+
+	rows, err = db.QueryContext(ctx, "SELECT|rowsstats|id|")
+	if err != nil {
+		t.Fatalf("Unexpected error from QueryContext: %v", err)
+	}
+	rows.Next()
+	err = rows.Close()
+	if err != nil {
+		t.Fatalf("Unexpected error from Close: %v", err)
+	}
+
+	if len(logger.rowsStats) != 2 {
+		t.Fatalf("Expected 2 rows stats, got %d", len(logger.rowsStats))
+	}
+
+	stats := logger.rowsStats[0]
+	if stats.Rows != 3 || !stats.EOF {
+		t.Errorf("Expected stats of fully read rows to have 3 rows and EOF, got %+v", stats)
+	}
+	if stats.Timing.Duration() <= 0 {
+		t.Errorf("Expected stats of rows to have a duration")
+	}
+
+	stats = logger.rowsStats[1]
+	if stats.Rows != 1 || stats.EOF {
+		t.Errorf("Expected stats of early closed rows to have 1 row and no EOF, got %+v", stats)
+	}
+}
+
+func TestLoggingConnector_ExecResult(t *testing.T) {
+	logger := newTestLogger()
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(logger, connector)
+
+	ctx := context.Background()
+
+	db := sql.OpenDB(loggingConnector)
+	_, err := db.ExecContext(ctx, "CREATE|execresult|id=int64")
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+	_, err = db.ExecContext(ctx, "INSERT|execresult|id=?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+
+	if len(logger.execResults) != 2 {
+		t.Fatalf("Expected 2 exec results, got %d", len(logger.execResults))
+	}
+	result := logger.execResults[1]
+	if result.RowsAffectedErr != nil || result.RowsAffected != 1 {
+		t.Errorf("Expected exec result of insert to have 1 affected row, got %+v", result)
+	}
+	if result.LastInsertIDErr == nil {
+		t.Errorf("Expected exec result of insert to have an error for unsupported last insert id")
+	}
+}
+
+func TestLoggingConnector_IDGenerator(t *testing.T) {
+	var events []sqllogger.Event
+	logger := sqllogger.FromEventLogger(sqllogger.EventLoggerFunc(func(ctx context.Context, ev sqllogger.Event) {
+		events = append(events, ev)
+	}))
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(logger, connector, sqllogger.WithIDGenerator(sqllogger.NewPerKindIDGenerator()))
+
+	ctx := context.Background()
+
+	db := sql.OpenDB(loggingConnector)
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, "CREATE|ids|id=int64")
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT|ids|id|")
+	if err != nil {
+		t.Fatalf("Unexpected error from QueryContext: %v", err)
+	}
+	_ = rows.Close()
+
+	expectedEvents := []sqllogger.Event{
+		{Op: sqllogger.OpConnect, ConnID: 1},
+		{Op: sqllogger.OpConnPrepareContext, ConnID: 1, StmtID: 1, Query: "CREATE|ids|id=int64"},
+		{Op: sqllogger.OpStmtExecContext, StmtID: 1, Query: "CREATE|ids|id=int64"},
+		{Op: sqllogger.OpStmtClose, StmtID: 1},
+		{Op: sqllogger.OpConnPrepareContext, ConnID: 1, StmtID: 2, Query: "SELECT|ids|id|"},
+		{Op: sqllogger.OpStmtQueryContext, StmtID: 2, RowsID: 1, Query: "SELECT|ids|id|"},
+		{Op: sqllogger.OpRowsClose, RowsID: 1},
+		{Op: sqllogger.OpStmtClose, StmtID: 2},
+	}
+	if len(events) != len(expectedEvents) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(expectedEvents), len(events), events)
+	}
+	for i, ev := range events {
+		expected := expectedEvents[i]
+		if ev.Op != expected.Op || ev.ConnID != expected.ConnID || ev.StmtID != expected.StmtID || ev.RowsID != expected.RowsID || ev.Query != expected.Query {
+			t.Errorf("Expected event %d to be %+v, got %+v", i, expected, ev)
+		}
+	}
+}
+
+func TestLoggingConnector_UniqueIDsAcrossConnectors(t *testing.T) {
+	var connIDs []int64
+	logger := sqllogger.FromEventLogger(sqllogger.EventLoggerFunc(func(ctx context.Context, ev sqllogger.Event) {
+		if ev.Op == sqllogger.OpConnect {
+			connIDs = append(connIDs, ev.ConnID)
+		}
+	}))
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		db := sql.OpenDB(sqllogger.LoggingConnector(logger, new(fakeConnector)))
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error from Conn: %v", err)
+		}
+		_ = conn.Close()
+		_ = db.Close()
+	}
+
+	if len(connIDs) != 2 || connIDs[0] == connIDs[1] {
+		t.Errorf("Expected unique connection IDs across connectors, got %v", connIDs)
+	}
+}
+
+func TestLoggingConnector_Lineage(t *testing.T) {
+	lineages := make(map[sqllogger.Operation]sqllogger.Lineage)
+	logger := sqllogger.FromEventLogger(sqllogger.EventLoggerFunc(func(ctx context.Context, ev sqllogger.Event) {
+		lineage, ok := sqllogger.GetLineage(ctx)
+		if !ok {
+			t.Errorf("Expected lineage for %s", ev.Op)
+		}
+		lineages[ev.Op] = lineage
+	}))
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(logger, connector, sqllogger.WithIDGenerator(sqllogger.NewPerKindIDGenerator()))
+
+	ctx := context.Background()
+
+	db := sql.OpenDB(loggingConnector)
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, "CREATE|lineage|id=int64")
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error from BeginTx: %v", err)
+	}
+	rows, err := tx.QueryContext(ctx, "SELECT|lineage|id|")
+	if err != nil {
+		t.Fatalf("Unexpected error from QueryContext: %v", err)
+	}
+	_ = rows.Close()
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf("Unexpected error from Commit: %v", err)
+	}
+
+	expectedLineages := map[sqllogger.Operation]sqllogger.Lineage{
+		sqllogger.OpConnBegin:          {ConnID: 1},
+		sqllogger.OpConnPrepareContext: {ConnID: 1, TxID: 1},
+		sqllogger.OpStmtQueryContext:   {ConnID: 1, TxID: 1},
+		sqllogger.OpRowsClose:          {ConnID: 1, StmtID: 2, TxID: 1},
+		sqllogger.OpStmtClose:          {ConnID: 1, TxID: 1},
+		sqllogger.OpTxCommit:           {ConnID: 1},
+	}
+	for op, expected := range expectedLineages {
+		if actual := lineages[op]; actual != expected {
+			t.Errorf("Expected lineage of %s to be %+v, got %+v", op, expected, actual)
+		}
+	}
+}
+
+func TestLoggingConnector_DriverWithoutDatabase(t *testing.T) {
+	var infos []sqllogger.DatabaseInfo
+	logger := sqllogger.FromEventLogger(sqllogger.EventLoggerFunc(func(ctx context.Context, ev sqllogger.Event) {
+		info, _ := sqllogger.GetDatabaseInfo(ctx)
+		infos = append(infos, info)
+	}))
+	loggingConnector := sqllogger.LoggingConnector(logger, new(fakeConnector))
+
+	conn, err := loggingConnector.Driver().Open("host=db password=secret")
+	if err != nil {
+		t.Fatalf("Unexpected error from Open: %v", err)
+	}
+	_ = conn.Close()
+
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(infos))
+	}
+	for _, info := range infos {
+		if info != (sqllogger.DatabaseInfo{}) {
+			t.Errorf("Expected no database info without WithDatabase, got %+v", info)
+		}
+	}
+}
+
+func TestLoggingConnector_Driver(t *testing.T) {
+	logger := newTestLogger()
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(logger, connector)
+
+	drv := loggingConnector.Driver()
+
+	conn, err := drv.Open("")
+	if err != nil {
+		t.Fatalf("Unexpected error from Open: %v", err)
+	}
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Unexpected error from Close: %v", err)
+	}
+
+	driverCtx, ok := drv.(driver.DriverContext)
+	if !ok {
+		t.Fatalf("Expected driver to implement driver.DriverContext")
+	}
+	dsnConnector, err := driverCtx.OpenConnector("")
+	if err != nil {
+		t.Fatalf("Unexpected error from OpenConnector: %v", err)
+	}
+	db := sql.OpenDB(dsnConnector)
+	defer db.Close()
+	_, err = db.ExecContext(context.Background(), "CREATE|driver|id=int64")
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+
+	expectedLogs := []string{
+		`Connect`,
+		`ConnClose`,
+		`Connect`,
+		`ConnPrepareContext`,
+		`StmtExecContext`,
+		`StmtClose`,
+	}
+	if len(logger.logs) != len(expectedLogs) {
+		t.Fatalf("Expected %d log entries, got %d: %+v", len(expectedLogs), len(logger.logs), logger.logs)
+	}
+	for i, actualEntry := range logger.logs {
+		if actualEntry != expectedLogs[i] {
+			t.Errorf("Expected log entry %d to be %q, got %q", i, expectedLogs[i], actualEntry)
+		}
+	}
+}
+
+func TestLoggingConnector_OnlyConnIDs(t *testing.T) {
+	logger := newTestLogger()
+	filteringLogger := sqllogger.NewFilteringLogger(logger, sqllogger.OnlyConnIDs(1))
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(filteringLogger, connector, sqllogger.WithIDGenerator(sqllogger.NewPerKindIDGenerator()))
+
+	db := sql.OpenDB(loggingConnector)
+	defer db.Close()
+
+	_, err := db.ExecContext(context.Background(), "CREATE|onlyconnids|id=int64")
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+
+	expectedLogs := []string{
+		`Connect`,
+		`ConnPrepareContext`,
+		`StmtExecContext`,
+		`StmtClose`,
+	}
+	if len(logger.logs) != len(expectedLogs) {
+		t.Fatalf("Expected %d log entries, got %d: %+v", len(expectedLogs), len(logger.logs), logger.logs)
+	}
+	for i, actualEntry := range logger.logs {
+		if actualEntry != expectedLogs[i] {
+			t.Errorf("Expected log entry %d to be %q, got %q", i, expectedLogs[i], actualEntry)
+		}
+	}
+}
+
 type testLogger struct {
-	logs []string
+	logs        []string
+	errors      []sqllogger.Event
+	rowsStats   []sqllogger.RowsStats
+	execResults []sqllogger.ExecResult
 }
 
 var _ sqllogger.SQLLogger = &testLogger{}
+var _ sqllogger.SQLErrorLogger = &testLogger{}
 
 func (tl *testLogger) Connect(ctx context.Context, connID int64) {
 	tl.logs = append(tl.logs, "Connect")
@@ -104,6 +456,9 @@ func (tl *testLogger) StmtExec(ctx context.Context, stmtID int64, query string,
 
 func (tl *testLogger) StmtExecContext(ctx context.Context, stmtID int64, query string, args []driver.NamedValue) {
 	tl.logs = append(tl.logs, "StmtExecContext")
+	if result, ok := sqllogger.GetExecResult(ctx); ok {
+		tl.execResults = append(tl.execResults, result)
+	}
 }
 
 func (tl *testLogger) StmtQuery(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.Value) {
@@ -120,6 +475,9 @@ func (tl *testLogger) StmtClose(ctx context.Context, stmtID int64) {
 
 func (tl *testLogger) RowsClose(ctx context.Context, rowsID int64) {
 	tl.logs = append(tl.logs, "RowsClose")
+	if stats, ok := sqllogger.GetRowsStats(ctx); ok {
+		tl.rowsStats = append(tl.rowsStats, stats)
+	}
 }
 
 func (tl *testLogger) TxCommit(ctx context.Context, txID int64) {
@@ -130,6 +488,11 @@ func (tl *testLogger) TxRollback(ctx context.Context, txID int64) {
 	tl.logs = append(tl.logs, "TxRollback")
 }
 
+func (tl *testLogger) OperationError(ctx context.Context, ev sqllogger.Event) {
+	tl.logs = append(tl.logs, "OperationError("+string(ev.Op)+")")
+	tl.errors = append(tl.errors, ev)
+}
+
 func newTestLogger() *testLogger {
 	return &testLogger{}
 }
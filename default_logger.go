@@ -3,6 +3,9 @@ package sqllogger
 import (
 	"context"
 	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
 )
 
 // StdLogger is an interface to adapt the DefaultSQLLogger to the standard library log.Logger or other log frameworks
@@ -31,16 +34,27 @@ type DefaultSQLLogger struct {
 
 	LogConnect bool
 	LogClose   bool
+	// LogRowsAffected appends the number of affected rows to exec operations (e.g. "→ 3 rows affected")
+	LogRowsAffected bool
+	// LogDuration appends the duration of the operation to each log entry (e.g. "[1.2ms]")
+	LogDuration bool
+	// InterpolateArgs logs queries with arguments substituted for placeholders, so they can be copied into a database client
+	InterpolateArgs bool
+	// Dialect selects the formatting of argument values when InterpolateArgs is set
+	Dialect Dialect
+	// Limits truncates long queries and large argument values
+	Limits Limits
 }
 
 var _ SQLLogger = &DefaultSQLLogger{}
+var _ SQLErrorLogger = &DefaultSQLLogger{}
 
 // TxRollback satisfies Logger interface
 func (dl *DefaultSQLLogger) TxRollback(ctx context.Context, txID int64) {
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("  TX(%d) ► Rollback", txID)
+	dl.printf(ctx, "  TX(%d) ► Rollback", txID)
 }
 
 // TxCommit satisfies Logger interface
@@ -48,7 +62,7 @@ func (dl *DefaultSQLLogger) TxCommit(ctx context.Context, txID int64) {
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("  TX(%d) ► Commit", txID)
+	dl.printf(ctx, "  TX(%d) ► Commit", txID)
 }
 
 // RowsClose satisfies Logger interface
@@ -57,7 +71,7 @@ func (dl *DefaultSQLLogger) RowsClose(ctx context.Context, rowsID int64) {
 		return
 	}
 	if dl.LogClose {
-		dl.log.Printf("ROWS(%d) ► Close", rowsID)
+		dl.printf(ctx, "ROWS(%d) ► Close", rowsID)
 	}
 }
 
@@ -69,7 +83,7 @@ func (dl *DefaultSQLLogger) Connect(ctx context.Context, connID int64) {
 	if !dl.LogConnect {
 		return
 	}
-	dl.log.Printf("Connect → CONN(%d)", connID)
+	dl.printf(ctx, "Connect → CONN(%d)", connID)
 }
 
 // ConnBegin satisfies Logger interface
@@ -77,7 +91,7 @@ func (dl *DefaultSQLLogger) ConnBegin(ctx context.Context, connID, txID int64, o
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("CONN(%d) ► Begin -> TX(%d)", connID, txID)
+	dl.printf(ctx, "CONN(%d) ► Begin -> TX(%d)", connID, txID)
 }
 
 // ConnPrepare satisfies Logger interface
@@ -85,7 +99,7 @@ func (dl *DefaultSQLLogger) ConnPrepare(ctx context.Context, connID, stmtID int6
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("CONN(%d) ► Prepare(%s) → STMT(%d)", connID, query, stmtID)
+	dl.printf(ctx, "CONN(%d) ► Prepare(%s) → STMT(%d)", connID, query, stmtID)
 }
 
 // ConnPrepareContext satisfies Logger interface
@@ -93,7 +107,7 @@ func (dl *DefaultSQLLogger) ConnPrepareContext(ctx context.Context, connID int64
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("CONN(%d) ► Prepare(%s) → STMT(%d)", connID, query, stmtID)
+	dl.printf(ctx, "CONN(%d) ► Prepare(%s) → STMT(%d)", connID, query, stmtID)
 }
 
 // ConnQuery satisfies Logger interface
@@ -101,7 +115,7 @@ func (dl *DefaultSQLLogger) ConnQuery(ctx context.Context, connID, rowsID int64,
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("CONN(%d) ► Query(%s) → ROWS(%d)", connID, query, rowsID)
+	dl.printf(ctx, "CONN(%d) ► Query(%s) → ROWS(%d)", connID, dl.query(query, Event{Args: args}.NamedValues()), rowsID)
 }
 
 // ConnQueryContext satisfies Logger interface
@@ -109,7 +123,7 @@ func (dl *DefaultSQLLogger) ConnQueryContext(ctx context.Context, connID int64,
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("CONN(%d) ► Query(%s) → ROWS(%d)", connID, query, rowsID)
+	dl.printf(ctx, "CONN(%d) ► Query(%s) → ROWS(%d)", connID, dl.query(query, args), rowsID)
 }
 
 // ConnExec satisfies Logger interface
@@ -117,7 +131,7 @@ func (dl *DefaultSQLLogger) ConnExec(ctx context.Context, connID int64, query st
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("CONN(%d) ► Exec(%s)", connID, query)
+	dl.printf(ctx, "CONN(%d) ► Exec(%s)%s", connID, dl.query(query, Event{Args: args}.NamedValues()), dl.rowsAffected(ctx))
 }
 
 // ConnExecContext satisfies Logger interface
@@ -125,7 +139,7 @@ func (dl *DefaultSQLLogger) ConnExecContext(ctx context.Context, connID int64, q
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("CONN(%d) ► Exec(%s)", connID, query)
+	dl.printf(ctx, "CONN(%d) ► Exec(%s)%s", connID, dl.query(query, args), dl.rowsAffected(ctx))
 }
 
 // ConnClose satisfies Logger interface
@@ -134,7 +148,7 @@ func (dl *DefaultSQLLogger) ConnClose(ctx context.Context, connID int64) {
 		return
 	}
 	if dl.LogClose {
-		dl.log.Printf("CONN(%d) ► Close", connID)
+		dl.printf(ctx, "CONN(%d) ► Close", connID)
 	}
 }
 
@@ -143,7 +157,7 @@ func (dl *DefaultSQLLogger) StmtExec(ctx context.Context, stmtID int64, query st
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("STMT(%d) ► Exec(%s)", stmtID, query)
+	dl.printf(ctx, "STMT(%d) ► Exec(%s)%s", stmtID, dl.query(query, Event{Args: args}.NamedValues()), dl.rowsAffected(ctx))
 }
 
 // StmtExecContext satisfies Logger interface
@@ -151,7 +165,7 @@ func (dl *DefaultSQLLogger) StmtExecContext(ctx context.Context, stmtID int64, q
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("STMT(%d) ► Exec(%s)", stmtID, query)
+	dl.printf(ctx, "STMT(%d) ► Exec(%s)%s", stmtID, dl.query(query, args), dl.rowsAffected(ctx))
 }
 
 // StmtQuery satisfies Logger interface
@@ -159,7 +173,7 @@ func (dl *DefaultSQLLogger) StmtQuery(ctx context.Context, stmtID, rowsID int64,
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("STMT(%d) ► Query(%s) → ROWS(%d)", stmtID, query, rowsID)
+	dl.printf(ctx, "STMT(%d) ► Query(%s) → ROWS(%d)", stmtID, dl.query(query, Event{Args: args}.NamedValues()), rowsID)
 }
 
 // StmtQueryContext satisfies Logger interface
@@ -167,7 +181,7 @@ func (dl *DefaultSQLLogger) StmtQueryContext(ctx context.Context, stmtID int64,
 	if !dl.Enabled {
 		return
 	}
-	dl.log.Printf("STMT(%d) ► Query(%s) → ROWS(%d)", stmtID, query, rowsID)
+	dl.printf(ctx, "STMT(%d) ► Query(%s) → ROWS(%d)", stmtID, dl.query(query, args), rowsID)
 }
 
 // StmtClose satisfies Logger interface
@@ -176,6 +190,96 @@ func (dl *DefaultSQLLogger) StmtClose(ctx context.Context, stmtID int64) {
 		return
 	}
 	if dl.LogClose {
-		dl.log.Printf("STMT(%d) ► Close", stmtID)
+		dl.printf(ctx, "STMT(%d) ► Close", stmtID)
+	}
+}
+
+// OperationError satisfies SQLErrorLogger interface
+func (dl *DefaultSQLLogger) OperationError(ctx context.Context, ev Event) {
+	if !dl.Enabled {
+		return
+	}
+
+	var subject string
+	switch {
+	case ev.Op == OpConnect:
+		dl.printf(ctx, "Connect ✗ %v", ev.Err)
+		return
+	case ev.RowsID != 0:
+		subject = fmt.Sprintf("ROWS(%d)", ev.RowsID)
+	case ev.StmtID != 0:
+		subject = fmt.Sprintf("STMT(%d)", ev.StmtID)
+	case ev.TxID != 0:
+		subject = fmt.Sprintf("TX(%d)", ev.TxID)
+	default:
+		subject = fmt.Sprintf("CONN(%d)", ev.ConnID)
+	}
+
+	name := operationName(ev.Op)
+	if ev.Query != "" {
+		dl.printf(ctx, "%s ► %s(%s) ✗ %v", subject, name, dl.query(ev.Query, ev.NamedValues()), ev.Err)
+		return
+	}
+	dl.printf(ctx, "%s ► %s ✗ %v", subject, name, ev.Err)
+}
+
+// operationName returns the short name of an operation without the type prefix and context suffix (e.g. "Exec")
+func operationName(op Operation) string {
+	name := string(op)
+	for _, prefix := range []string{"Conn", "Stmt", "Rows", "Tx"} {
+		if strings.HasPrefix(name, prefix) {
+			name = name[len(prefix):]
+			break
+		}
+	}
+	return strings.TrimSuffix(name, "Context")
+}
+
+func (dl *DefaultSQLLogger) printf(ctx context.Context, format string, args ...interface{}) {
+	if IsSlow(ctx) {
+		format = "SLOW " + format
+	}
+	if label, ok := GetConnectorLabel(ctx); ok {
+		format = "[%s] " + format
+		args = append([]interface{}{label}, args...)
+	}
+	if dl.LogDuration {
+		if timing, ok := GetTiming(ctx); ok {
+			format += " [%s]"
+			args = append(args, formatDuration(timing.Duration()))
+		}
+	}
+	dl.log.Printf(format, args...)
+}
+
+func (dl *DefaultSQLLogger) query(query string, args []driver.NamedValue) string {
+	if dl.InterpolateArgs {
+		return dl.Limits.Interpolate(dl.Dialect, query, args)
+	}
+	return dl.Limits.TruncateQuery(query)
+}
+
+func (dl *DefaultSQLLogger) rowsAffected(ctx context.Context) string {
+	if !dl.LogRowsAffected {
+		return ""
+	}
+	result, ok := GetExecResult(ctx)
+	if !ok || result.RowsAffectedErr != nil {
+		return ""
+	}
+	return fmt.Sprintf(" → %d rows affected", result.RowsAffected)
+}
+
+// formatDuration rounds the duration to a precision that is suitable for logging
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(100 * time.Microsecond).String()
+	case d >= time.Microsecond:
+		return d.Round(time.Microsecond).String()
+	default:
+		return d.String()
 	}
 }
@@ -2,8 +2,11 @@ package sqllogger
 
 import (
 	"context"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 )
 
 type testLogger []string
@@ -32,3 +35,142 @@ func TestNewDefaultSQLLogger(t *testing.T) {
 		}
 	}
 }
+
+func TestDefaultSQLLogger_OperationError(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	defaultSQLLogger.OperationError(context.Background(), Event{
+		Op:     OpConnExecContext,
+		ConnID: 1,
+		Query:  "SELECT 1",
+		Err:    errors.New("boom"),
+	})
+	defaultSQLLogger.OperationError(context.Background(), Event{
+		Op:   OpTxCommit,
+		TxID: 2,
+		Err:  errors.New("conflict"),
+	})
+
+	expectedEntries := []string{
+		"CONN(1) ► Exec(SELECT 1) ✗ boom",
+		"TX(2) ► Commit ✗ conflict",
+	}
+
+	if len(l) != len(expectedEntries) {
+		t.Fatalf("expect %d log entries, but got %d", len(expectedEntries), len(l))
+	}
+
+	for i, entry := range l {
+		if entry != expectedEntries[i] {
+			t.Errorf("log entry at index %d expected to be %q, but got %q", i, expectedEntries[i], entry)
+		}
+	}
+}
+
+func TestDefaultSQLLogger_LogDuration(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	defaultSQLLogger.LogDuration = true
+
+	start := time.Now()
+	ctx := WithTiming(context.Background(), Timing{Start: start, End: start.Add(1234 * time.Microsecond)})
+	defaultSQLLogger.ConnQueryContext(ctx, 3, 4, "SELECT 1", nil)
+	defaultSQLLogger.ConnQueryContext(context.Background(), 3, 5, "SELECT 2", nil)
+
+	expectedEntries := []string{
+		"CONN(3) ► Query(SELECT 1) → ROWS(4) [1.2ms]",
+		"CONN(3) ► Query(SELECT 2) → ROWS(5)",
+	}
+
+	if len(l) != len(expectedEntries) {
+		t.Fatalf("expect %d log entries, but got %d", len(expectedEntries), len(l))
+	}
+
+	for i, entry := range l {
+		if entry != expectedEntries[i] {
+			t.Errorf("log entry at index %d expected to be %q, but got %q", i, expectedEntries[i], entry)
+		}
+	}
+}
+
+func TestDefaultSQLLogger_LogRowsAffected(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	defaultSQLLogger.LogRowsAffected = true
+
+	ctx := WithExecResult(context.Background(), ExecResult{RowsAffected: 1204})
+	defaultSQLLogger.ConnExecContext(ctx, 1, "UPDATE users SET active = false", nil)
+
+	expectedEntry := "CONN(1) ► Exec(UPDATE users SET active = false) → 1204 rows affected"
+	if len(l) != 1 || l[0] != expectedEntry {
+		t.Errorf("expected log entry %q, but got %q", expectedEntry, l)
+	}
+}
+
+func TestDefaultSQLLogger_InterpolateArgs(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	defaultSQLLogger.InterpolateArgs = true
+
+	defaultSQLLogger.StmtExecContext(context.Background(), 3, "UPDATE users SET name = $1 WHERE id = $2", []driver.NamedValue{
+		{Ordinal: 1, Value: "O'Brien"},
+		{Ordinal: 2, Value: int64(42)},
+	})
+	defaultSQLLogger.ConnQuery(context.Background(), 1, 4, "SELECT * FROM users WHERE id = ?", []driver.Value{int64(7)})
+
+	expectedEntries := []string{
+		"STMT(3) ► Exec(UPDATE users SET name = 'O''Brien' WHERE id = 42)",
+		"CONN(1) ► Query(SELECT * FROM users WHERE id = 7) → ROWS(4)",
+	}
+	if fmt.Sprint(l) != fmt.Sprint(expectedEntries) {
+		t.Errorf("expected log entries %q, but got %q", expectedEntries, l)
+	}
+}
+
+func TestDefaultSQLLogger_Limits(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	defaultSQLLogger.InterpolateArgs = true
+	defaultSQLLogger.Limits = Limits{MaxQueryLength: 55, MaxArgBytes: 3}
+
+	defaultSQLLogger.ConnExec(context.Background(), 1, "INSERT INTO docs (body) VALUES (?), (?), (?)", []driver.Value{"lorem ipsum", "b", "c"})
+
+	expectedEntry := "CONN(1) ► Exec(INSERT INTO docs (body) VALUES ('lor…(+8 more)'), ('b')…(+7 more))"
+	if len(l) != 1 || l[0] != expectedEntry {
+		t.Errorf("expected log entry %q, but got %q", expectedEntry, l)
+	}
+}
+
+func TestDefaultSQLLogger_Label(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+
+	ctx := WithDatabaseInfo(context.Background(), DatabaseInfo{Label: "replica", DriverName: "postgres", Host: "db:5432"})
+	defaultSQLLogger.TxCommit(ctx, 2)
+
+	expectedEntry := "[replica]   TX(2) ► Commit"
+	if len(l) != 1 || l[0] != expectedEntry {
+		t.Errorf("expected log entry %q, but got %q", expectedEntry, l)
+	}
+}
+
+func TestDefaultSQLLogger_LimitsMaxArgs(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	defaultSQLLogger.InterpolateArgs = true
+	defaultSQLLogger.Limits = Limits{MaxArgs: 1}
+
+	defaultSQLLogger.ConnExec(context.Background(), 1, "INSERT INTO t VALUES (?), (?), (?)", []driver.Value{int64(1), int64(2), int64(3)})
+
+	expectedEntry := "CONN(1) ► Exec(INSERT INTO t VALUES (1), (?), (?) …(+2 more))"
+	if len(l) != 1 || l[0] != expectedEntry {
+		t.Errorf("expected log entry %q, but got %q", expectedEntry, l)
+	}
+}
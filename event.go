@@ -0,0 +1,130 @@
+package sqllogger
+
+import (
+	"database/sql/driver"
+)
+
+// Operation identifies an intercepted operation, it matches the name of the corresponding SQLLogger method
+type Operation string
+
+const (
+	OpConnect            Operation = "Connect"
+	OpConnBegin          Operation = "ConnBegin"
+	OpConnPrepare        Operation = "ConnPrepare"
+	OpConnPrepareContext Operation = "ConnPrepareContext"
+	OpConnQuery          Operation = "ConnQuery"
+	OpConnQueryContext   Operation = "ConnQueryContext"
+	OpConnExec           Operation = "ConnExec"
+	OpConnExecContext    Operation = "ConnExecContext"
+	OpConnClose          Operation = "ConnClose"
+	OpStmtExec           Operation = "StmtExec"
+	OpStmtExecContext    Operation = "StmtExecContext"
+	OpStmtQuery          Operation = "StmtQuery"
+	OpStmtQueryContext   Operation = "StmtQueryContext"
+	OpStmtClose          Operation = "StmtClose"
+	OpRowsClose          Operation = "RowsClose"
+	OpTxCommit           Operation = "TxCommit"
+	OpTxRollback         Operation = "TxRollback"
+)
+
+// OperationKind groups operations by their type regardless of the object they are called on
+type OperationKind string
+
+const (
+	KindConnect  OperationKind = "connect"
+	KindBegin    OperationKind = "begin"
+	KindPrepare  OperationKind = "prepare"
+	KindQuery    OperationKind = "query"
+	KindExec     OperationKind = "exec"
+	KindClose    OperationKind = "close"
+	KindCommit   OperationKind = "commit"
+	KindRollback OperationKind = "rollback"
+)
+
+// Kind returns the kind of the operation
+func (op Operation) Kind() OperationKind {
+	switch op {
+	case OpConnect:
+		return KindConnect
+	case OpConnBegin:
+		return KindBegin
+	case OpConnPrepare, OpConnPrepareContext:
+		return KindPrepare
+	case OpConnQuery, OpConnQueryContext, OpStmtQuery, OpStmtQueryContext:
+		return KindQuery
+	case OpConnExec, OpConnExecContext, OpStmtExec, OpStmtExecContext:
+		return KindExec
+	case OpConnClose, OpStmtClose, OpRowsClose:
+		return KindClose
+	case OpTxCommit:
+		return KindCommit
+	case OpTxRollback:
+		return KindRollback
+	}
+	return ""
+}
+
+// Event describes a single intercepted operation with all the values passed to the SQLLogger
+//
+// IDs that are not known for an operation are zero. Depending on the operation, either Args or NamedArgs are set.
+type Event struct {
+	Op Operation
+
+	ConnID int64
+	StmtID int64
+	RowsID int64
+	TxID   int64
+
+	Query     string
+	Args      []driver.Value
+	NamedArgs []driver.NamedValue
+	TxOptions driver.TxOptions
+
+	// Err is the error returned by the original operation, it is only set for events passed to SQLErrorLogger
+	Err error
+}
+
+// NamedValues returns the arguments of the event as named values, Args are converted with their ordinal position
+func (ev Event) NamedValues() []driver.NamedValue {
+	if ev.NamedArgs != nil || ev.Args == nil {
+		return ev.NamedArgs
+	}
+	named := make([]driver.NamedValue, len(ev.Args))
+	for i, v := range ev.Args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+// Clone returns a copy of the event with copied arguments, so it can be retained after the SQLLogger call returned
+//
+// Drivers may reuse argument slices and byte slices after an operation, so events must be cloned before retaining them.
+func (ev Event) Clone() Event {
+	if ev.Args != nil {
+		args := make([]driver.Value, len(ev.Args))
+		for i, v := range ev.Args {
+			args[i] = cloneValue(v)
+		}
+		ev.Args = args
+	}
+	if ev.NamedArgs != nil {
+		ev.NamedArgs = cloneNamedValues(ev.NamedArgs)
+	}
+	return ev
+}
+
+func cloneNamedValues(named []driver.NamedValue) []driver.NamedValue {
+	cloned := make([]driver.NamedValue, len(named))
+	for i, nv := range named {
+		nv.Value = cloneValue(nv.Value)
+		cloned[i] = nv
+	}
+	return cloned
+}
+
+func cloneValue(v driver.Value) driver.Value {
+	if b, ok := v.([]byte); ok {
+		return append([]byte(nil), b...)
+	}
+	return v
+}
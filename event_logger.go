@@ -0,0 +1,158 @@
+package sqllogger
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// EventLogger is a simplified logger interface that receives every intercepted operation as a single Event
+//
+// It can be used with FromEventLogger to implement a SQLLogger without implementing every single method.
+type EventLogger interface {
+	// LogEvent is called for every operation, failed operations have ev.Err set.
+	LogEvent(ctx context.Context, ev Event)
+}
+
+// EventLoggerFunc is an adapter to use a function as an EventLogger
+type EventLoggerFunc func(ctx context.Context, ev Event)
+
+// LogEvent satisfies EventLogger interface
+func (f EventLoggerFunc) LogEvent(ctx context.Context, ev Event) {
+	f(ctx, ev)
+}
+
+// FromEventLogger returns a SQLLogger that passes every call as an Event to the given EventLogger
+//
+// The returned logger also implements SQLErrorLogger, so failed operations are passed to the EventLogger with ev.Err set.
+func FromEventLogger(l EventLogger) SQLLogger {
+	return &eventSQLLogger{l: l}
+}
+
+type eventSQLLogger struct {
+	l EventLogger
+}
+
+var _ SQLLogger = &eventSQLLogger{}
+var _ SQLErrorLogger = &eventSQLLogger{}
+
+func (e *eventSQLLogger) Connect(ctx context.Context, connID int64) {
+	e.l.LogEvent(ctx, Event{Op: OpConnect, ConnID: connID})
+}
+
+func (e *eventSQLLogger) ConnBegin(ctx context.Context, connID, txID int64, opts driver.TxOptions) {
+	e.l.LogEvent(ctx, Event{Op: OpConnBegin, ConnID: connID, TxID: txID, TxOptions: opts})
+}
+
+func (e *eventSQLLogger) ConnPrepare(ctx context.Context, connID, stmtID int64, query string) {
+	e.l.LogEvent(ctx, Event{Op: OpConnPrepare, ConnID: connID, StmtID: stmtID, Query: query})
+}
+
+func (e *eventSQLLogger) ConnPrepareContext(ctx context.Context, connID int64, stmtID int64, query string) {
+	e.l.LogEvent(ctx, Event{Op: OpConnPrepareContext, ConnID: connID, StmtID: stmtID, Query: query})
+}
+
+func (e *eventSQLLogger) ConnQuery(ctx context.Context, connID, rowsID int64, query string, args []driver.Value) {
+	e.l.LogEvent(ctx, Event{Op: OpConnQuery, ConnID: connID, RowsID: rowsID, Query: query, Args: args})
+}
+
+func (e *eventSQLLogger) ConnQueryContext(ctx context.Context, connID int64, rowsID int64, query string, args []driver.NamedValue) {
+	e.l.LogEvent(ctx, Event{Op: OpConnQueryContext, ConnID: connID, RowsID: rowsID, Query: query, NamedArgs: args})
+}
+
+func (e *eventSQLLogger) ConnExec(ctx context.Context, connID int64, query string, args []driver.Value) {
+	e.l.LogEvent(ctx, Event{Op: OpConnExec, ConnID: connID, Query: query, Args: args})
+}
+
+func (e *eventSQLLogger) ConnExecContext(ctx context.Context, connID int64, query string, args []driver.NamedValue) {
+	e.l.LogEvent(ctx, Event{Op: OpConnExecContext, ConnID: connID, Query: query, NamedArgs: args})
+}
+
+func (e *eventSQLLogger) ConnClose(ctx context.Context, connID int64) {
+	e.l.LogEvent(ctx, Event{Op: OpConnClose, ConnID: connID})
+}
+
+func (e *eventSQLLogger) StmtExec(ctx context.Context, stmtID int64, query string, args []driver.Value) {
+	e.l.LogEvent(ctx, Event{Op: OpStmtExec, StmtID: stmtID, Query: query, Args: args})
+}
+
+func (e *eventSQLLogger) StmtExecContext(ctx context.Context, stmtID int64, query string, args []driver.NamedValue) {
+	e.l.LogEvent(ctx, Event{Op: OpStmtExecContext, StmtID: stmtID, Query: query, NamedArgs: args})
+}
+
+func (e *eventSQLLogger) StmtQuery(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.Value) {
+	e.l.LogEvent(ctx, Event{Op: OpStmtQuery, StmtID: stmtID, RowsID: rowsID, Query: query, Args: args})
+}
+
+func (e *eventSQLLogger) StmtQueryContext(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.NamedValue) {
+	e.l.LogEvent(ctx, Event{Op: OpStmtQueryContext, StmtID: stmtID, RowsID: rowsID, Query: query, NamedArgs: args})
+}
+
+func (e *eventSQLLogger) StmtClose(ctx context.Context, stmtID int64) {
+	e.l.LogEvent(ctx, Event{Op: OpStmtClose, StmtID: stmtID})
+}
+
+func (e *eventSQLLogger) RowsClose(ctx context.Context, rowsID int64) {
+	e.l.LogEvent(ctx, Event{Op: OpRowsClose, RowsID: rowsID})
+}
+
+func (e *eventSQLLogger) TxCommit(ctx context.Context, txID int64) {
+	e.l.LogEvent(ctx, Event{Op: OpTxCommit, TxID: txID})
+}
+
+func (e *eventSQLLogger) TxRollback(ctx context.Context, txID int64) {
+	e.l.LogEvent(ctx, Event{Op: OpTxRollback, TxID: txID})
+}
+
+func (e *eventSQLLogger) OperationError(ctx context.Context, ev Event) {
+	e.l.LogEvent(ctx, ev)
+}
+
+// Dispatch calls the method of the given SQLLogger matching the operation of the event
+//
+// Events with Err set are passed to OperationError if the logger implements SQLErrorLogger and dropped otherwise.
+// This is the counterpart of FromEventLogger and can be used to forward events in wrapping loggers.
+func (ev Event) Dispatch(ctx context.Context, log SQLLogger) {
+	if ev.Err != nil {
+		if errLog, ok := log.(SQLErrorLogger); ok {
+			errLog.OperationError(ctx, ev)
+		}
+		return
+	}
+
+	switch ev.Op {
+	case OpConnect:
+		log.Connect(ctx, ev.ConnID)
+	case OpConnBegin:
+		log.ConnBegin(ctx, ev.ConnID, ev.TxID, ev.TxOptions)
+	case OpConnPrepare:
+		log.ConnPrepare(ctx, ev.ConnID, ev.StmtID, ev.Query)
+	case OpConnPrepareContext:
+		log.ConnPrepareContext(ctx, ev.ConnID, ev.StmtID, ev.Query)
+	case OpConnQuery:
+		log.ConnQuery(ctx, ev.ConnID, ev.RowsID, ev.Query, ev.Args)
+	case OpConnQueryContext:
+		log.ConnQueryContext(ctx, ev.ConnID, ev.RowsID, ev.Query, ev.NamedArgs)
+	case OpConnExec:
+		log.ConnExec(ctx, ev.ConnID, ev.Query, ev.Args)
+	case OpConnExecContext:
+		log.ConnExecContext(ctx, ev.ConnID, ev.Query, ev.NamedArgs)
+	case OpConnClose:
+		log.ConnClose(ctx, ev.ConnID)
+	case OpStmtExec:
+		log.StmtExec(ctx, ev.StmtID, ev.Query, ev.Args)
+	case OpStmtExecContext:
+		log.StmtExecContext(ctx, ev.StmtID, ev.Query, ev.NamedArgs)
+	case OpStmtQuery:
+		log.StmtQuery(ctx, ev.StmtID, ev.RowsID, ev.Query, ev.Args)
+	case OpStmtQueryContext:
+		log.StmtQueryContext(ctx, ev.StmtID, ev.RowsID, ev.Query, ev.NamedArgs)
+	case OpStmtClose:
+		log.StmtClose(ctx, ev.StmtID)
+	case OpRowsClose:
+		log.RowsClose(ctx, ev.RowsID)
+	case OpTxCommit:
+		log.TxCommit(ctx, ev.TxID)
+	case OpTxRollback:
+		log.TxRollback(ctx, ev.TxID)
+	}
+}
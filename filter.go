@@ -0,0 +1,117 @@
+package sqllogger
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// Predicate decides whether an operation should be logged
+type Predicate func(ctx context.Context, ev Event) bool
+
+// NewFilteringLogger creates a SQLLogger that only forwards operations to the given logger if all predicates match
+//
+// Failed operations are passed to the predicates with ev.Err set and forwarded if the wrapped logger implements
+// SQLErrorLogger.
+func NewFilteringLogger(log SQLLogger, predicates ...Predicate) SQLLogger {
+	return FromEventLogger(EventLoggerFunc(func(ctx context.Context, ev Event) {
+		for _, p := range predicates {
+			if !p(ctx, ev) {
+				return
+			}
+		}
+		ev.Dispatch(ctx, log)
+	}))
+}
+
+// OnlyKinds matches operations of the given kinds
+func OnlyKinds(kinds ...OperationKind) Predicate {
+	return func(ctx context.Context, ev Event) bool {
+		kind := ev.Op.Kind()
+		for _, k := range kinds {
+			if k == kind {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipKinds matches operations that are not of the given kinds
+func SkipKinds(kinds ...OperationKind) Predicate {
+	return Not(OnlyKinds(kinds...))
+}
+
+// SkipQueries matches operations without a query or with a query not matching any of the patterns
+// (e.g. health checks with `^SELECT 1$`)
+func SkipQueries(patterns ...*regexp.Regexp) Predicate {
+	return func(ctx context.Context, ev Event) bool {
+		for _, pattern := range patterns {
+			if ev.Query != "" && pattern.MatchString(ev.Query) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// OnlyConnIDs matches operations on the given connections, operations on statements, rows and transactions match by
+// the connection of their lineage (see GetLineage)
+func OnlyConnIDs(connIDs ...int64) Predicate {
+	return func(ctx context.Context, ev Event) bool {
+		connID := ev.ConnID
+		if connID == 0 {
+			lineage, _ := GetLineage(ctx)
+			connID = lineage.ConnID
+		}
+		for _, id := range connIDs {
+			if connID == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ContextValue matches operations with a context that has the given value for the key, values are compared with
+// reflect.DeepEqual, so uncomparable values like slices or maps can be used
+func ContextValue(key, value interface{}) Predicate {
+	return func(ctx context.Context, ev Event) bool {
+		return reflect.DeepEqual(ctx.Value(key), value)
+	}
+}
+
+// MinDuration matches operations with a Timing of at least the given duration, operations without timing do not match
+func MinDuration(d time.Duration) Predicate {
+	return func(ctx context.Context, ev Event) bool {
+		timing, ok := GetTiming(ctx)
+		return ok && timing.Duration() >= d
+	}
+}
+
+// Failed matches failed operations
+func Failed() Predicate {
+	return func(ctx context.Context, ev Event) bool {
+		return ev.Err != nil
+	}
+}
+
+// Not inverts the predicate
+func Not(p Predicate) Predicate {
+	return func(ctx context.Context, ev Event) bool {
+		return !p(ctx, ev)
+	}
+}
+
+// Any matches if any of the predicates matches
+func Any(predicates ...Predicate) Predicate {
+	return func(ctx context.Context, ev Event) bool {
+		for _, p := range predicates {
+			if p(ctx, ev) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,60 @@
+package sqllogger
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type skipLoggingKey struct{}
+
+func TestNewFilteringLogger(t *testing.T) {
+	var l testLogger
+
+	log := NewFilteringLogger(NewDefaultSQLLogger(&l),
+		SkipKinds(KindConnect, KindClose),
+		SkipQueries(regexp.MustCompile(`^SELECT 1$`), regexp.MustCompile(`schema_migrations`)),
+		Not(ContextValue(skipLoggingKey{}, true)),
+		Any(Failed(), MinDuration(time.Millisecond), OnlyKinds(KindCommit)),
+	)
+
+	ctx := context.Background()
+	start := time.Now()
+	timedCtx := WithTiming(ctx, Timing{Start: start, End: start.Add(2 * time.Millisecond)})
+
+	log.Connect(timedCtx, 1)
+	log.ConnQueryContext(timedCtx, 1, 2, "SELECT 1", nil)
+	log.ConnExecContext(timedCtx, 1, "INSERT INTO schema_migrations (version) VALUES (1)", nil)
+	log.ConnExecContext(context.WithValue(timedCtx, skipLoggingKey{}, true), 1, "DELETE FROM sessions", nil)
+	log.ConnExecContext(ctx, 1, "DELETE FROM users", nil)
+	log.ConnExecContext(timedCtx, 1, "DELETE FROM users", nil)
+	log.(SQLErrorLogger).OperationError(ctx, Event{Op: OpConnExecContext, ConnID: 1, Query: "DELETE FROM teams", Err: errors.New("boom")})
+	log.TxCommit(ctx, 3)
+
+	expectedEntries := []string{
+		"CONN(1) ► Exec(DELETE FROM users)",
+		"CONN(1) ► Exec(DELETE FROM teams) ✗ boom",
+		"  TX(3) ► Commit",
+	}
+	if len(l) != len(expectedEntries) {
+		t.Fatalf("expected log entries %q, but got %q", expectedEntries, l)
+	}
+	for i, entry := range expectedEntries {
+		if l[i] != entry {
+			t.Errorf("expected log entry %d to be %q, but got %q", i, entry, l[i])
+		}
+	}
+}
+
+func TestContextValue_Uncomparable(t *testing.T) {
+	p := ContextValue(skipLoggingKey{}, []string{"health"})
+
+	if !p(context.WithValue(context.Background(), skipLoggingKey{}, []string{"health"}), Event{}) {
+		t.Errorf("expected equal slice to match")
+	}
+	if p(context.WithValue(context.Background(), skipLoggingKey{}, map[string]bool{"health": true}), Event{}) {
+		t.Errorf("expected map not to match")
+	}
+}
@@ -0,0 +1,116 @@
+package sqllogger
+
+import (
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// NormalizeQuery returns a normalized form of the query that is the same for all executions of a statement shape
+//
+// String and numeric literals as well as placeholders ($1, :name, @p1) are replaced by "?", lists of values in IN
+// clauses are collapsed to a single "?", comments are removed and whitespace is collapsed to a single space.
+func NormalizeQuery(query string) string {
+	var sb strings.Builder
+	sb.Grow(len(query))
+
+	runes := []rune(query)
+	n := len(runes)
+	pendingSpace := false
+	emit := func(s string) {
+		if pendingSpace && sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		pendingSpace = false
+		sb.WriteString(s)
+	}
+	prevIdent := func(i int) bool {
+		return i > 0 && isIdentRune(runes[i-1])
+	}
+
+	for i := 0; i < n; i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			pendingSpace = true
+		case r == '-' && i+1 < n && runes[i+1] == '-':
+			for i < n && runes[i] != '\n' {
+				i++
+			}
+			pendingSpace = true
+		case r == '/' && i+1 < n && runes[i+1] == '*':
+			i += 2
+			for i < n && !(runes[i] == '*' && i+1 < n && runes[i+1] == '/') {
+				i++
+			}
+			i++
+			pendingSpace = true
+		case r == '\'':
+			// String literal with '' as escaped quote
+			i++
+			for i < n {
+				if runes[i] == '\'' {
+					if i+1 < n && runes[i+1] == '\'' {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			emit("?")
+		case r == '"' || r == '`':
+			// Quoted identifier is kept as is
+			start := i
+			i++
+			for i < n && runes[i] != r {
+				i++
+			}
+			end := i + 1
+			if end > n {
+				end = n
+			}
+			emit(string(runes[start:end]))
+		case unicode.IsDigit(r) && !prevIdent(i):
+			for i+1 < n && (isIdentRune(runes[i+1]) || runes[i+1] == '.' ||
+				((runes[i+1] == '+' || runes[i+1] == '-') && (runes[i] == 'e' || runes[i] == 'E'))) {
+				i++
+			}
+			emit("?")
+		case (r == '$' || r == '@') && i+1 < n && isIdentRune(runes[i+1]) && !prevIdent(i):
+			for i+1 < n && isIdentRune(runes[i+1]) {
+				i++
+			}
+			emit("?")
+		case r == ':' && i+1 < n && unicode.IsLetter(runes[i+1]) && !(i > 0 && runes[i-1] == ':'):
+			for i+1 < n && isIdentRune(runes[i+1]) {
+				i++
+			}
+			emit("?")
+		default:
+			emit(string(r))
+		}
+	}
+
+	return inListPattern.ReplaceAllString(sb.String(), "IN (?)")
+}
+
+var inListPattern = regexp.MustCompile(`(?i)\bIN\s*\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Fingerprint returns a stable hash of the normalized query, so queries that only differ in literals, placeholders,
+// lengths of IN lists or whitespace get the same fingerprint
+func Fingerprint(query string) string {
+	return fingerprintNormalized(NormalizeQuery(query))
+}
+
+func fingerprintNormalized(normalized string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(normalized))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
@@ -0,0 +1,59 @@
+package sqllogger
+
+import (
+	"testing"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{
+			query:    "SELECT * FROM users WHERE id = 42",
+			expected: "SELECT * FROM users WHERE id = ?",
+		},
+		{
+			query:    "SELECT *\n  FROM users\tWHERE name = 'O''Brien' AND score > 1.5e-3",
+			expected: "SELECT * FROM users WHERE name = ? AND score > ?",
+		},
+		{
+			query:    "SELECT * FROM users WHERE id IN ($1, $2, $3) AND tenant = :tenant",
+			expected: "SELECT * FROM users WHERE id IN (?) AND tenant = ?",
+		},
+		{
+			query:    "select * from users where id in (1,2) and created_at > @p1::timestamp",
+			expected: "select * from users where id IN (?) and created_at > ?::timestamp",
+		},
+		{
+			// Backslash is no escape character in standard SQL (PostgreSQL with standard_conforming_strings)
+			query:    `SELECT * FROM t WHERE path = 'C:\' AND id = 5 AND x IN (1,2,3)`,
+			expected: "SELECT * FROM t WHERE path = ? AND id = ? AND x IN (?)",
+		},
+		{
+			query:    `SELECT "table1".col2 FROM "table1" -- comment` + "\n" + `/* block */ LIMIT 10`,
+			expected: `SELECT "table1".col2 FROM "table1" LIMIT ?`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			actual := NormalizeQuery(tt.query)
+			if actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := Fingerprint("SELECT * FROM users WHERE id IN (1, 2, 3)")
+	b := Fingerprint("SELECT  *  FROM users WHERE id IN (4)")
+	c := Fingerprint("SELECT * FROM accounts WHERE id IN (4)")
+
+	if a != b {
+		t.Errorf("expected same fingerprint for same statement shape, got %s and %s", a, b)
+	}
+	if a == c {
+		t.Errorf("expected different fingerprint for different statements")
+	}
+}
@@ -0,0 +1,99 @@
+package sqllogger
+
+import (
+	"time"
+)
+
+// latencyBuckets are the upper bounds of the buckets of latency histograms
+var latencyBuckets = [...]time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	1 * time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// HistogramBucket is a bucket of a latency histogram
+type HistogramBucket struct {
+	// UpperBound is the inclusive upper bound of the bucket
+	UpperBound time.Duration
+	// Count is the cumulative count of observations less than or equal to the upper bound
+	Count int64
+}
+
+// latencyHistogram records durations in fixed buckets, it is not safe for concurrent use
+type latencyHistogram struct {
+	// counts has one additional bucket for observations above the last upper bound
+	counts [len(latencyBuckets) + 1]int64
+	count  int64
+	sum    time.Duration
+	min    time.Duration
+	max    time.Duration
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	i := 0
+	for i < len(latencyBuckets) && d > latencyBuckets[i] {
+		i++
+	}
+	h.counts[i]++
+
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count++
+	h.sum += d
+}
+
+// buckets returns the cumulative buckets of the histogram without the overflow bucket
+func (h *latencyHistogram) buckets() []HistogramBucket {
+	buckets := make([]HistogramBucket, len(latencyBuckets))
+	var cumulative int64
+	for i, upperBound := range latencyBuckets {
+		cumulative += h.counts[i]
+		buckets[i] = HistogramBucket{UpperBound: upperBound, Count: cumulative}
+	}
+	return buckets
+}
+
+// quantile estimates the q-quantile (e.g. 0.95) by interpolating linearly inside the matching bucket
+func (h *latencyHistogram) quantile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := q * float64(h.count)
+
+	var cumulative int64
+	for i, count := range h.counts {
+		if count == 0 || float64(cumulative+count) < rank {
+			cumulative += count
+			continue
+		}
+
+		lower := h.min
+		if i > 0 && latencyBuckets[i-1] > lower {
+			lower = latencyBuckets[i-1]
+		}
+		upper := h.max
+		if i < len(latencyBuckets) && latencyBuckets[i] < upper {
+			upper = latencyBuckets[i]
+		}
+		fraction := (rank - float64(cumulative)) / float64(count)
+		return lower + time.Duration(fraction*float64(upper-lower))
+	}
+	return h.max
+}
@@ -0,0 +1,136 @@
+package sqllogger
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IDKind identifies the type of object an ID is generated for
+type IDKind string
+
+const (
+	IDConn IDKind = "conn"
+	IDStmt IDKind = "stmt"
+	IDRows IDKind = "rows"
+	IDTx   IDKind = "tx"
+)
+
+// IDGenerator generates the IDs of connections, statements, rows and transactions passed to the SQLLogger
+//
+// Implementations must be safe for concurrent use. A generator can be set per connector with WithIDGenerator.
+type IDGenerator interface {
+	// NextID returns a new ID for an object of the given kind, IDs must not be zero
+	NextID(kind IDKind) int64
+}
+
+// IDGeneratorFunc is an adapter to use a function as an IDGenerator
+type IDGeneratorFunc func(kind IDKind) int64
+
+// NextID satisfies IDGenerator interface
+func (f IDGeneratorFunc) NextID(kind IDKind) int64 {
+	return f(kind)
+}
+
+// defaultIDGenerator is shared by all connectors without an IDGenerator, so IDs are unique within the process and
+// loggers shared by multiple connectors can keep state by ID
+var defaultIDGenerator = NewSequenceIDGenerator()
+
+// NewSequenceIDGenerator creates an IDGenerator with a single counter for all kinds, so every ID is unique within the
+// generator
+//
+// Connectors without an IDGenerator share a process-wide sequence. Setting a new sequence per connector restarts IDs
+// at 1, so loggers shared by multiple connectors must not rely on IDs being unique.
+func NewSequenceIDGenerator() IDGenerator {
+	return &sequenceIDGenerator{}
+}
+
+type sequenceIDGenerator struct {
+	seq atomic.Int64
+}
+
+func (g *sequenceIDGenerator) NextID(kind IDKind) int64 {
+	return g.seq.Add(1)
+}
+
+// NewPerKindIDGenerator creates an IDGenerator with a separate counter per kind (e.g. CONN(1), STMT(1), TX(1))
+func NewPerKindIDGenerator() IDGenerator {
+	return &perKindIDGenerator{}
+}
+
+type perKindIDGenerator struct {
+	conn, stmt, rows, tx, other atomic.Int64
+}
+
+func (g *perKindIDGenerator) NextID(kind IDKind) int64 {
+	switch kind {
+	case IDConn:
+		return g.conn.Add(1)
+	case IDStmt:
+		return g.stmt.Add(1)
+	case IDRows:
+		return g.rows.Add(1)
+	case IDTx:
+		return g.tx.Add(1)
+	}
+	return g.other.Add(1)
+}
+
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	// MaxSnowflakeNode is the maximum node number of NewSnowflakeIDGenerator
+	MaxSnowflakeNode = 1<<snowflakeNodeBits - 1
+)
+
+// snowflakeEpoch is the start of the timestamps of snowflake IDs (2020-01-01T00:00:00Z)
+var snowflakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// NewSnowflakeIDGenerator creates an IDGenerator for IDs that are unique across service instances with a distinct node
+// number between 0 and MaxSnowflakeNode
+//
+// IDs consist of a millisecond timestamp (41 bits), the node (10 bits) and a sequence (12 bits). They are sortable by
+// time, so logs of multiple replicas can be correlated. IDs are int64, so string based IDs like UUIDs or ULIDs cannot
+// be generated by an IDGenerator.
+func NewSnowflakeIDGenerator(node int64) (IDGenerator, error) {
+	if node < 0 || node > MaxSnowflakeNode {
+		return nil, fmt.Errorf("snowflake node must be between 0 and %d, got %d", MaxSnowflakeNode, node)
+	}
+	return &snowflakeIDGenerator{
+		node: node,
+		now:  time.Now,
+	}, nil
+}
+
+type snowflakeIDGenerator struct {
+	node int64
+	now  func() time.Time
+
+	mx       sync.Mutex
+	lastTime int64
+	sequence int64
+}
+
+func (g *snowflakeIDGenerator) NextID(kind IDKind) int64 {
+	g.mx.Lock()
+	defer g.mx.Unlock()
+
+	ts := g.now().Sub(snowflakeEpoch).Milliseconds()
+	if ts < g.lastTime {
+		// Clock moved backwards, continue with the last timestamp to keep IDs unique
+		ts = g.lastTime
+	}
+	if ts == g.lastTime {
+		g.sequence = (g.sequence + 1) & (1<<snowflakeSequenceBits - 1)
+		if g.sequence == 0 {
+			// Sequence exhausted, borrow the next millisecond
+			ts++
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastTime = ts
+
+	return ts<<(snowflakeNodeBits+snowflakeSequenceBits) | g.node<<snowflakeSequenceBits | g.sequence
+}
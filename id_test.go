@@ -0,0 +1,53 @@
+package sqllogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPerKindIDGenerator(t *testing.T) {
+	g := NewPerKindIDGenerator()
+
+	actual := []int64{g.NextID(IDConn), g.NextID(IDStmt), g.NextID(IDConn), g.NextID(IDRows), g.NextID(IDTx)}
+	expected := []int64{1, 1, 2, 1, 1}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected IDs %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestSnowflakeIDGenerator(t *testing.T) {
+	if _, err := NewSnowflakeIDGenerator(MaxSnowflakeNode + 1); err == nil {
+		t.Fatalf("expected error for invalid node")
+	}
+
+	ids, err := NewSnowflakeIDGenerator(7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g := ids.(*snowflakeIDGenerator)
+	now := snowflakeEpoch.Add(1500 * time.Millisecond)
+	g.now = func() time.Time { return now }
+
+	first := g.NextID(IDConn)
+	second := g.NextID(IDStmt)
+	now = now.Add(-time.Millisecond)
+	third := g.NextID(IDRows)
+
+	if expected := int64(1500<<22 | 7<<12); first != expected {
+		t.Errorf("expected first ID %d, got %d", expected, first)
+	}
+	if second != first+1 || third != first+2 {
+		t.Errorf("expected increasing IDs, got %d, %d, %d", first, second, third)
+	}
+
+	// Exhausting the sequence continues with the next millisecond
+	var last int64
+	for i := 0; i < 1<<snowflakeSequenceBits; i++ {
+		last = g.NextID(IDTx)
+	}
+	if last>>22 != 1501 {
+		t.Errorf("expected timestamp of ID to be 1501, got %d", last>>22)
+	}
+}
@@ -0,0 +1,197 @@
+package sqllogger
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Dialect controls how argument values are formatted as SQL literals by Interpolate
+type Dialect int
+
+const (
+	// DialectStandard formats values as standard SQL literals (bytes as X'...')
+	DialectStandard Dialect = iota
+	// DialectPostgres formats values for PostgreSQL (bytes as '\x...', timestamps with time zone offset)
+	DialectPostgres
+	// DialectMySQL formats values for MySQL (bytes as X'...', backslashes in strings are escaped)
+	DialectMySQL
+)
+
+// Interpolate returns the query with all placeholders substituted by the formatted argument values using DialectStandard
+//
+// See Dialect.Interpolate for supported placeholder styles.
+func Interpolate(query string, args []driver.NamedValue) string {
+	return DialectStandard.Interpolate(query, args)
+}
+
+// Interpolate returns the query with all placeholders substituted by the formatted argument values, so the query
+// can be copied into a database client
+//
+// Placeholders in the styles ?, $1, :name and @p1 (or @name) are supported. Placeholders inside string literals,
+// quoted identifiers and comments are ignored. Placeholders without a matching argument are kept as is.
+// Note: the result is only meant for logging and must never be executed, since values are not escaped for every
+// possible database configuration.
+func (d Dialect) Interpolate(query string, args []driver.NamedValue) string {
+	if len(args) == 0 {
+		return query
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(query) + len(args)*8)
+
+	runes := []rune(query)
+	n := len(runes)
+	position := 0
+	identEnd := func(i int) int {
+		for i < n && isIdentRune(runes[i]) {
+			i++
+		}
+		return i
+	}
+	writeArg := func(nv *driver.NamedValue, placeholder []rune) {
+		if nv == nil {
+			sb.WriteString(string(placeholder))
+			return
+		}
+		sb.WriteString(d.FormatValue(nv.Value))
+	}
+
+	for i := 0; i < n; i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' || r == '"' || r == '`':
+			// Copy literals and quoted identifiers
+			end := i + 1
+			for end < n && runes[end] != r {
+				end++
+			}
+			if end < n {
+				end++
+			}
+			sb.WriteString(string(runes[i:end]))
+			i = end - 1
+		case r == '-' && i+1 < n && runes[i+1] == '-':
+			end := i
+			for end < n && runes[end] != '\n' {
+				end++
+			}
+			sb.WriteString(string(runes[i:end]))
+			i = end - 1
+		case r == '/' && i+1 < n && runes[i+1] == '*':
+			end := strings.Index(string(runes[i:]), "*/")
+			if end == -1 {
+				sb.WriteString(string(runes[i:]))
+				i = n
+				continue
+			}
+			comment := string(runes[i:])[:end+2]
+			sb.WriteString(comment)
+			i += len([]rune(comment)) - 1
+		case r == '?':
+			position++
+			writeArg(argByOrdinal(args, position), runes[i:i+1])
+		case r == '$' && i+1 < n && unicode.IsDigit(runes[i+1]):
+			end := identEnd(i + 1)
+			ordinal, err := strconv.Atoi(string(runes[i+1 : end]))
+			if err != nil {
+				sb.WriteString(string(runes[i:end]))
+			} else {
+				writeArg(argByOrdinal(args, ordinal), runes[i:end])
+			}
+			i = end - 1
+		case r == ':' && i+1 < n && unicode.IsLetter(runes[i+1]) && !(i > 0 && runes[i-1] == ':'):
+			end := identEnd(i + 1)
+			writeArg(argByName(args, string(runes[i+1:end])), runes[i:end])
+			i = end - 1
+		case r == '@' && i+1 < n && unicode.IsLetter(runes[i+1]):
+			end := identEnd(i + 1)
+			name := string(runes[i+1 : end])
+			nv := argByName(args, name)
+			if nv == nil && len(name) > 1 && (name[0] == 'p' || name[0] == 'P') {
+				if ordinal, err := strconv.Atoi(name[1:]); err == nil {
+					nv = argByOrdinal(args, ordinal)
+				}
+			}
+			writeArg(nv, runes[i:end])
+			i = end - 1
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+func argByOrdinal(args []driver.NamedValue, ordinal int) *driver.NamedValue {
+	for i := range args {
+		if args[i].Ordinal == ordinal {
+			return &args[i]
+		}
+	}
+	return nil
+}
+
+func argByName(args []driver.NamedValue, name string) *driver.NamedValue {
+	for i := range args {
+		if args[i].Name != "" && args[i].Name == name {
+			return &args[i]
+		}
+	}
+	return nil
+}
+
+// FormatValue formats a single value as SQL literal
+func (d Dialect) FormatValue(v driver.Value) string {
+	if valuer, ok := v.(driver.Valuer); ok {
+		var err error
+		v, err = valuer.Value()
+		if err != nil {
+			return fmt.Sprintf("/* %v */ NULL", err)
+		}
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return d.quoteString(v)
+	case []byte:
+		if v == nil {
+			return "NULL"
+		}
+		if d == DialectPostgres {
+			return `'\x` + hex.EncodeToString(v) + `'`
+		}
+		return "X'" + strings.ToUpper(hex.EncodeToString(v)) + "'"
+	case time.Time:
+		if d == DialectPostgres {
+			return "'" + v.Format("2006-01-02 15:04:05.999999-07:00") + "'"
+		}
+		return "'" + v.Format("2006-01-02 15:04:05.999999") + "'"
+	default:
+		return d.quoteString(fmt.Sprint(v))
+	}
+}
+
+func (d Dialect) quoteString(s string) string {
+	if d == DialectMySQL {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,54 @@
+package sqllogger
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestInterpolate(t *testing.T) {
+	ts := time.Date(2024, 5, 14, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		query    string
+		args     []driver.NamedValue
+		expected string
+	}{
+		{
+			name:     "question mark placeholders",
+			query:    "SELECT * FROM users WHERE name = ? AND active = ? AND deleted_at IS ?",
+			args:     []driver.NamedValue{{Ordinal: 1, Value: "O'Brien"}, {Ordinal: 2, Value: true}, {Ordinal: 3, Value: nil}},
+			expected: "SELECT * FROM users WHERE name = 'O''Brien' AND active = TRUE AND deleted_at IS NULL",
+		},
+		{
+			name:     "numbered placeholders",
+			dialect:  DialectPostgres,
+			query:    "UPDATE users SET data = $2, updated_at = $3 WHERE id = $1 AND note <> '$1'",
+			args:     []driver.NamedValue{{Ordinal: 1, Value: int64(42)}, {Ordinal: 2, Value: []byte{0xca, 0xfe}}, {Ordinal: 3, Value: ts}},
+			expected: `UPDATE users SET data = '\xcafe', updated_at = '2024-05-14 12:30:00+02:00' WHERE id = 42 AND note <> '$1'`,
+		},
+		{
+			name:     "named placeholders",
+			query:    "SELECT :a::text, :b -- :a",
+			args:     []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1.5}, {Name: "b", Ordinal: 2, Value: []byte{0x01}}},
+			expected: "SELECT 1.5::text, X'01' -- :a",
+		},
+		{
+			name:     "sql server placeholders",
+			dialect:  DialectMySQL,
+			query:    "SELECT @p1, @p2, @missing",
+			args:     []driver.NamedValue{{Ordinal: 1, Value: `a\b`}, {Ordinal: 2, Value: ts}},
+			expected: `SELECT 'a\\b', '2024-05-14 12:30:00', @missing`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.dialect.Interpolate(tt.query, tt.args)
+			if actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
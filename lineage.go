@@ -0,0 +1,29 @@
+package sqllogger
+
+import (
+	"context"
+)
+
+// Lineage contains the IDs of the parent objects of an operation, IDs that are not known are zero
+//
+// For example, a StmtExec call has the connection of the statement and the active transaction of the connection as
+// parents, a RowsClose call the connection, statement and transaction the rows were queried on. Operations on a
+// connection have the connection itself and its active transaction as lineage.
+type Lineage struct {
+	ConnID int64
+	StmtID int64
+	TxID   int64
+}
+
+type lineageKey struct{}
+
+// WithLineage returns a new context with the given lineage, it is set by LoggingConnector for all SQLLogger calls
+func WithLineage(ctx context.Context, lineage Lineage) context.Context {
+	return context.WithValue(ctx, lineageKey{}, lineage)
+}
+
+// GetLineage returns the lineage of the operation from the context, if set
+func GetLineage(ctx context.Context) (Lineage, bool) {
+	lineage, ok := ctx.Value(lineageKey{}).(Lineage)
+	return lineage, ok
+}
@@ -1,6 +1,7 @@
 package logrusadapter
 
 import (
+	"context"
 	"database/sql/driver"
 
 	"github.com/networkteam/go-sqllogger"
@@ -30,6 +31,7 @@ type SQLLogger struct {
 }
 
 var _ sqllogger.SQLLogger = SQLLogger{}
+var _ sqllogger.SQLErrorLogger = SQLLogger{}
 
 type Opts struct {
 	ConnectLevel logrus.Level
@@ -38,6 +40,46 @@ type Opts struct {
 	ExecLevel    logrus.Level
 	CloseLevel   logrus.Level
 	TxLevel      logrus.Level
+	ErrorLevel   logrus.Level
+
+	// InterpolateArgs logs the query with arguments substituted for placeholders instead of separate query and args fields
+	InterpolateArgs bool
+	// Dialect selects the formatting of argument values when InterpolateArgs is set
+	Dialect sqllogger.Dialect
+	// Limits truncates long queries and large argument values
+	Limits sqllogger.Limits
+}
+
+// entry creates a log entry with the context, the duration of the operation and the database, if available
+func (l SQLLogger) entry(ctx context.Context) *logrus.Entry {
+	entry := l.logrusLogger.WithContext(ctx)
+	if timing, ok := sqllogger.GetTiming(ctx); ok {
+		entry = entry.WithField("duration", timing.Duration())
+	}
+	if info, ok := sqllogger.GetDatabaseInfo(ctx); ok {
+		if info.Label != "" {
+			entry = entry.WithField("db", info.Label)
+		}
+		if info.DriverName != "" {
+			entry = entry.WithField("dbDriver", info.DriverName)
+		}
+		if info.Host != "" {
+			entry = entry.WithField("dbHost", info.Host)
+		}
+	}
+	return entry
+}
+
+// queryFields returns the query and args fields of the event
+func (l SQLLogger) queryFields(ev sqllogger.Event) logrus.Fields {
+	limits := l.opts.Limits
+	if l.opts.InterpolateArgs {
+		return logrus.Fields{"query": limits.Interpolate(l.opts.Dialect, ev.Query, ev.NamedValues())}
+	}
+	if ev.Args != nil {
+		return logrus.Fields{"query": limits.TruncateQuery(ev.Query), "args": limits.TruncateValues(ev.Args)}
+	}
+	return logrus.Fields{"query": limits.TruncateQuery(ev.Query), "args": limits.TruncateArgs(ev.NamedArgs)}
 }
 
 func DefaultOpts() Opts {
@@ -48,130 +90,147 @@ func DefaultOpts() Opts {
 		ExecLevel:    logrus.InfoLevel,
 		CloseLevel:   logrus.DebugLevel,
 		TxLevel:      logrus.InfoLevel,
+		ErrorLevel:   logrus.ErrorLevel,
 	}
 }
 
-func (l SQLLogger) Connect(connID int64) {
-	l.logrusLogger.
+func (l SQLLogger) Connect(ctx context.Context, connID int64) {
+	l.entry(ctx).
 		WithField("connID", connID).
 		Log(l.opts.ConnectLevel, "DB Connect")
 }
 
-func (l SQLLogger) ConnBegin(connID, txID int64, opts driver.TxOptions) {
-	l.logrusLogger.
+func (l SQLLogger) ConnBegin(ctx context.Context, connID, txID int64, opts driver.TxOptions) {
+	l.entry(ctx).
 		WithField("connID", connID).
+		WithField("txID", txID).
 		Log(l.opts.TxLevel, "CONN Begin")
 }
 
-func (l SQLLogger) ConnPrepare(connID, stmtID int64, query string) {
-	l.logrusLogger.
+func (l SQLLogger) ConnPrepare(ctx context.Context, connID, stmtID int64, query string) {
+	l.entry(ctx).
 		WithField("connID", connID).
-		WithField("query", query).
+		WithField("query", l.opts.Limits.TruncateQuery(query)).
 		WithField("stmtID", stmtID).
 		Log(l.opts.PrepareLevel, "CONN Prepare")
 }
 
-func (l SQLLogger) ConnPrepareContext(connID int64, stmtID int64, query string) {
-	l.logrusLogger.
+func (l SQLLogger) ConnPrepareContext(ctx context.Context, connID int64, stmtID int64, query string) {
+	l.entry(ctx).
 		WithField("connID", connID).
-		WithField("query", query).
+		WithField("query", l.opts.Limits.TruncateQuery(query)).
 		WithField("stmtID", stmtID).
 		Log(l.opts.PrepareLevel, "CONN Prepare")
 }
 
-func (l SQLLogger) ConnQuery(connID, rowsID int64, query string, args []driver.Value) {
-	l.logrusLogger.
+func (l SQLLogger) ConnQuery(ctx context.Context, connID, rowsID int64, query string, args []driver.Value) {
+	l.entry(ctx).
 		WithField("connID", connID).
-		WithField("query", query).
-		WithField("args", args).
+		WithFields(l.queryFields(sqllogger.Event{Query: query, Args: args})).
 		WithField("rowsID", rowsID).
 		Log(l.opts.QueryLevel, "CONN Query")
 }
 
-func (l SQLLogger) ConnQueryContext(connID int64, rowsID int64, query string, args []driver.NamedValue) {
-	l.logrusLogger.
+func (l SQLLogger) ConnQueryContext(ctx context.Context, connID int64, rowsID int64, query string, args []driver.NamedValue) {
+	l.entry(ctx).
 		WithField("connID", connID).
-		WithField("query", query).
-		WithField("args", args).
+		WithFields(l.queryFields(sqllogger.Event{Query: query, NamedArgs: args})).
 		WithField("rowsID", rowsID).
 		Log(l.opts.QueryLevel, "CONN Query")
 }
 
-func (l SQLLogger) ConnExec(connID int64, query string, args []driver.Value) {
-	l.logrusLogger.
+func (l SQLLogger) ConnExec(ctx context.Context, connID int64, query string, args []driver.Value) {
+	l.entry(ctx).
 		WithField("connID", connID).
-		WithField("query", query).
+		WithFields(l.queryFields(sqllogger.Event{Query: query, Args: args})).
 		Log(l.opts.ExecLevel, "CONN Exec")
 }
 
-func (l SQLLogger) ConnExecContext(connID int64, query string, args []driver.NamedValue) {
-	l.logrusLogger.
+func (l SQLLogger) ConnExecContext(ctx context.Context, connID int64, query string, args []driver.NamedValue) {
+	l.entry(ctx).
 		WithField("connID", connID).
-		WithField("query", query).
-		WithField("args", args).
+		WithFields(l.queryFields(sqllogger.Event{Query: query, NamedArgs: args})).
 		Log(l.opts.ExecLevel, "CONN Exec")
 }
 
-func (l SQLLogger) ConnClose(connID int64) {
-	l.logrusLogger.
+func (l SQLLogger) ConnClose(ctx context.Context, connID int64) {
+	l.entry(ctx).
 		WithField("connID", connID).
 		Log(l.opts.CloseLevel, "CONN Close")
 }
 
-func (l SQLLogger) StmtExec(stmtID int64, query string, args []driver.Value) {
-	l.logrusLogger.
+func (l SQLLogger) StmtExec(ctx context.Context, stmtID int64, query string, args []driver.Value) {
+	l.entry(ctx).
 		WithField("stmtID", stmtID).
-		WithField("query", query).
-		WithField("args", args).
+		WithFields(l.queryFields(sqllogger.Event{Query: query, Args: args})).
 		Log(l.opts.ExecLevel, "STMT Exec")
 }
 
-func (l SQLLogger) StmtExecContext(stmtID int64, query string, args []driver.NamedValue) {
-	l.logrusLogger.
+func (l SQLLogger) StmtExecContext(ctx context.Context, stmtID int64, query string, args []driver.NamedValue) {
+	l.entry(ctx).
 		WithField("stmtID", stmtID).
-		WithField("query", query).
-		WithField("args", args).
+		WithFields(l.queryFields(sqllogger.Event{Query: query, NamedArgs: args})).
 		Log(l.opts.ExecLevel, "STMT Exec")
 }
 
-func (l SQLLogger) StmtQuery(stmtID int64, rowsID int64, query string, args []driver.Value) {
-	l.logrusLogger.
+func (l SQLLogger) StmtQuery(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.Value) {
+	l.entry(ctx).
 		WithField("stmtID", stmtID).
-		WithField("query", query).
-		WithField("args", args).
+		WithFields(l.queryFields(sqllogger.Event{Query: query, Args: args})).
 		WithField("rowsID", rowsID).
 		Log(l.opts.QueryLevel, "STMT Query")
 }
 
-func (l SQLLogger) StmtQueryContext(stmtID int64, rowsID int64, query string, args []driver.NamedValue) {
-	l.logrusLogger.
+func (l SQLLogger) StmtQueryContext(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.NamedValue) {
+	l.entry(ctx).
 		WithField("stmtID", stmtID).
-		WithField("query", query).
-		WithField("args", args).
+		WithFields(l.queryFields(sqllogger.Event{Query: query, NamedArgs: args})).
 		WithField("rowsID", rowsID).
 		Log(l.opts.QueryLevel, "STMT Query")
 }
 
-func (l SQLLogger) StmtClose(stmtID int64) {
-	l.logrusLogger.
+func (l SQLLogger) StmtClose(ctx context.Context, stmtID int64) {
+	l.entry(ctx).
 		WithField("stmtID", stmtID).
 		Log(l.opts.CloseLevel, "STMT Close")
 }
 
-func (l SQLLogger) RowsClose(rowsID int64) {
-	l.logrusLogger.
+func (l SQLLogger) RowsClose(ctx context.Context, rowsID int64) {
+	l.entry(ctx).
 		WithField("rowsID", rowsID).
 		Log(l.opts.CloseLevel, "ROWS Close")
 }
 
-func (l SQLLogger) TxCommit(txID int64) {
-	l.logrusLogger.
+func (l SQLLogger) TxCommit(ctx context.Context, txID int64) {
+	l.entry(ctx).
 		WithField("txID", txID).
 		Log(l.opts.TxLevel, "TX Commit")
 }
 
-func (l SQLLogger) TxRollback(txID int64) {
-	l.logrusLogger.
+func (l SQLLogger) TxRollback(ctx context.Context, txID int64) {
+	l.entry(ctx).
 		WithField("txID", txID).
 		Log(l.opts.TxLevel, "TX Rollback")
 }
+
+func (l SQLLogger) OperationError(ctx context.Context, ev sqllogger.Event) {
+	entry := l.entry(ctx).WithField("op", string(ev.Op))
+	if ev.ConnID != 0 {
+		entry = entry.WithField("connID", ev.ConnID)
+	}
+	if ev.StmtID != 0 {
+		entry = entry.WithField("stmtID", ev.StmtID)
+	}
+	if ev.RowsID != 0 {
+		entry = entry.WithField("rowsID", ev.RowsID)
+	}
+	if ev.TxID != 0 {
+		entry = entry.WithField("txID", ev.TxID)
+	}
+	if ev.Query != "" {
+		entry = entry.WithFields(l.queryFields(ev))
+	}
+	entry.
+		WithError(ev.Err).
+		Log(l.opts.ErrorLevel, "SQL Error")
+}
@@ -2,12 +2,15 @@ package logrusadapter_test
 
 import (
 	"bytes"
+	"context"
 	"database/sql/driver"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/networkteam/go-sqllogger"
 	"github.com/networkteam/go-sqllogger/logrusadapter"
 )
 
@@ -18,16 +21,22 @@ func TestNewSQLLogger(t *testing.T) {
 	logger.SetOutput(&out)
 
 	sqlLogger := logrusadapter.NewSQLLogger(logger)
-	sqlLogger.Connect(42)
-	sqlLogger.ConnBegin(42, 43, driver.TxOptions{})
-	sqlLogger.ConnQuery(42, 44, "SELECT 1", nil)
-	sqlLogger.TxCommit(43)
-	sqlLogger.ConnClose(42)
+	ctx := context.Background()
+	start := time.Now()
+	timedCtx := sqllogger.WithTiming(ctx, sqllogger.Timing{Start: start, End: start.Add(1500 * time.Microsecond)})
+
+	sqlLogger.Connect(ctx, 42)
+	sqlLogger.ConnBegin(ctx, 42, 43, driver.TxOptions{})
+	sqlLogger.ConnQuery(ctx, 42, 44, "SELECT 1", nil)
+	sqlLogger.ConnExec(timedCtx, 42, "DELETE FROM users", nil)
+	sqlLogger.TxCommit(ctx, 43)
+	sqlLogger.ConnClose(ctx, 42)
 
 	actualLog := out.String()
 	expectedLogLines := []string{
-		`level=info msg="CONN Begin" connID=42`,
+		`level=info msg="CONN Begin" connID=42 txID=43`,
 		`level=info msg="CONN Query" args="[]" connID=42 query="SELECT 1" rowsID=44`,
+		`level=info msg="CONN Exec" args="[]" connID=42 duration=1.5ms query="DELETE FROM users"`,
 		`level=info msg="TX Commit" txID=43`,
 	}
 	for i, logLine := range expectedLogLines {
@@ -37,3 +46,41 @@ func TestNewSQLLogger(t *testing.T) {
 	}
 
 }
+
+func TestSQLLogger_InterpolateArgs(t *testing.T) {
+	var out bytes.Buffer
+
+	logger := logrus.New()
+	logger.SetOutput(&out)
+
+	opts := logrusadapter.DefaultOpts()
+	opts.InterpolateArgs = true
+	sqlLogger := logrusadapter.NewSQLLogger(logger, opts)
+
+	sqlLogger.StmtExecContext(context.Background(), 3, "UPDATE users SET name = $1 WHERE id = $2", []driver.NamedValue{
+		{Ordinal: 1, Value: "Ada"},
+		{Ordinal: 2, Value: int64(42)},
+	})
+
+	expectedLogLine := `level=info msg="STMT Exec" query="UPDATE users SET name = 'Ada' WHERE id = 42" stmtID=3`
+	if actualLog := out.String(); !strings.Contains(actualLog, expectedLogLine) {
+		t.Fatalf("expected log line:\n%s\n, but got:\n%s\n", expectedLogLine, actualLog)
+	}
+}
+
+func TestSQLLogger_DatabaseInfo(t *testing.T) {
+	var out bytes.Buffer
+
+	logger := logrus.New()
+	logger.SetOutput(&out)
+
+	sqlLogger := logrusadapter.NewSQLLogger(logger)
+	ctx := sqllogger.WithDatabaseInfo(context.Background(), sqllogger.DatabaseInfo{Label: "replica", DriverName: "postgres", Host: "db:5432"})
+
+	sqlLogger.TxCommit(ctx, 2)
+
+	expectedLogLine := `level=info msg="TX Commit" db=replica dbDriver=postgres dbHost="db:5432" txID=2`
+	if actualLog := out.String(); !strings.Contains(actualLog, expectedLogLine) {
+		t.Fatalf("expected log line:\n%s\n, but got:\n%s\n", expectedLogLine, actualLog)
+	}
+}
@@ -0,0 +1,169 @@
+package sqllogger
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"sync"
+	"time"
+)
+
+// NewMetricsLogger creates a SQLLogger that aggregates counters and latency histograms per operation kind
+// and tracks the number of open connections, statements, rows and transactions
+//
+// The metrics can be read with Snapshot, published via expvar with PublishExpvar or exported by an adapter
+// (e.g. the prometheusadapter module).
+func NewMetricsLogger() *MetricsLogger {
+	m := &MetricsLogger{
+		operations: make(map[OperationKind]*operationMetrics),
+	}
+	m.eventSQLLogger.l = m
+	return m
+}
+
+// MetricsLogger is a SQLLogger collecting metrics about all operations, it is safe for concurrent use
+type MetricsLogger struct {
+	eventSQLLogger
+
+	mx         sync.Mutex
+	operations map[OperationKind]*operationMetrics
+	openConns  int64
+	openStmts  int64
+	openRows   int64
+	openTxs    int64
+}
+
+var _ SQLLogger = &MetricsLogger{}
+var _ SQLErrorLogger = &MetricsLogger{}
+var _ expvar.Var = &MetricsLogger{}
+
+type operationMetrics struct {
+	errors    int64
+	histogram latencyHistogram
+}
+
+// MetricsSnapshot is a point in time copy of the metrics of a MetricsLogger
+type MetricsSnapshot struct {
+	// Operations contains metrics for each kind of operation that was observed
+	Operations map[OperationKind]OperationMetrics
+
+	OpenConns int64
+	OpenStmts int64
+	OpenRows  int64
+	OpenTxs   int64
+}
+
+// OperationMetrics contains the metrics of a single operation kind
+type OperationMetrics struct {
+	// Count is the number of operations, including failed operations
+	Count int64
+	// Errors is the number of failed operations
+	Errors int64
+	// TotalDuration is the sum of the durations of all operations
+	TotalDuration time.Duration
+	// Buckets is the cumulative latency histogram of all operations
+	Buckets []HistogramBucket
+}
+
+// LogEvent satisfies EventLogger interface
+func (m *MetricsLogger) LogEvent(ctx context.Context, ev Event) {
+	kind := ev.Op.Kind()
+
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	om := m.operations[kind]
+	if om == nil {
+		om = &operationMetrics{}
+		m.operations[kind] = om
+	}
+	if ev.Err != nil {
+		om.errors++
+	}
+	var d time.Duration
+	if timing, ok := GetTiming(ctx); ok {
+		d = timing.Duration()
+	}
+
+	// Close operations are called with and without an error, so they are only observed once
+	if ev.Err == nil || kind != KindClose {
+		om.histogram.observe(d)
+	}
+
+	m.trackOpen(ev)
+}
+
+func (m *MetricsLogger) trackOpen(ev Event) {
+	switch ev.Op {
+	case OpConnect:
+		if ev.Err == nil {
+			m.openConns++
+		}
+	case OpConnClose:
+		if ev.Err == nil {
+			m.openConns--
+		}
+	case OpConnPrepare, OpConnPrepareContext:
+		if ev.Err == nil {
+			m.openStmts++
+		}
+	case OpStmtClose:
+		if ev.Err == nil {
+			m.openStmts--
+		}
+	case OpConnQuery, OpConnQueryContext, OpStmtQuery, OpStmtQueryContext:
+		if ev.Err == nil {
+			m.openRows++
+		}
+	case OpRowsClose:
+		if ev.Err == nil {
+			m.openRows--
+		}
+	case OpConnBegin:
+		if ev.Err == nil {
+			m.openTxs++
+		}
+	case OpTxCommit, OpTxRollback:
+		// A transaction is finished after commit or rollback, even if it failed
+		m.openTxs--
+	}
+}
+
+// Snapshot returns a copy of the current metrics
+func (m *MetricsLogger) Snapshot() MetricsSnapshot {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	snapshot := MetricsSnapshot{
+		Operations: make(map[OperationKind]OperationMetrics, len(m.operations)),
+		OpenConns:  m.openConns,
+		OpenStmts:  m.openStmts,
+		OpenRows:   m.openRows,
+		OpenTxs:    m.openTxs,
+	}
+	for kind, om := range m.operations {
+		snapshot.Operations[kind] = OperationMetrics{
+			Count:         om.histogram.count,
+			Errors:        om.errors,
+			TotalDuration: om.histogram.sum,
+			Buckets:       om.histogram.buckets(),
+		}
+	}
+	return snapshot
+}
+
+// String returns the snapshot of the metrics as JSON and satisfies the expvar.Var interface
+func (m *MetricsLogger) String() string {
+	b, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// PublishExpvar publishes the metrics with the given name via expvar
+//
+// Like expvar.Publish, it panics if the name is already registered.
+func (m *MetricsLogger) PublishExpvar(name string) {
+	expvar.Publish(name, m)
+}
@@ -0,0 +1,81 @@
+package sqllogger_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/networkteam/go-sqllogger"
+)
+
+func TestMetricsLogger(t *testing.T) {
+	metricsLogger := sqllogger.NewMetricsLogger()
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(metricsLogger, connector)
+
+	ctx := context.Background()
+
+	db := sql.OpenDB(loggingConnector)
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, "CREATE|metrics|id=int64")
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+	_, err = db.ExecContext(ctx, "INSERT|metrics|id=?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+	_, err = db.ExecContext(ctx, "INSERT|unknown|id=?", 1)
+	if err == nil {
+		t.Fatalf("Expected error from ExecContext")
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT|metrics|id|")
+	if err != nil {
+		t.Fatalf("Unexpected error from QueryContext: %v", err)
+	}
+
+	snapshot := metricsLogger.Snapshot()
+	if snapshot.OpenConns != 1 {
+		t.Errorf("Expected 1 open connection, got %d", snapshot.OpenConns)
+	}
+	if snapshot.OpenRows != 1 {
+		t.Errorf("Expected 1 open rows, got %d", snapshot.OpenRows)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		t.Fatalf("Unexpected error from Close: %v", err)
+	}
+
+	snapshot = metricsLogger.Snapshot()
+	if snapshot.OpenRows != 0 || snapshot.OpenStmts != 0 {
+		t.Errorf("Expected no open rows and statements, got %d and %d", snapshot.OpenRows, snapshot.OpenStmts)
+	}
+
+	exec := snapshot.Operations[sqllogger.KindExec]
+	if exec.Count != 2 || exec.Errors != 0 {
+		t.Errorf("Expected 2 exec operations without errors, got %+v", exec)
+	}
+	prepare := snapshot.Operations[sqllogger.KindPrepare]
+	if prepare.Count != 4 || prepare.Errors != 1 {
+		t.Errorf("Expected 4 prepare operations with 1 error, got %+v", prepare)
+	}
+	query := snapshot.Operations[sqllogger.KindQuery]
+	if query.Count != 1 {
+		t.Errorf("Expected 1 query operation, got %+v", query)
+	}
+	if len(query.Buckets) == 0 || query.Buckets[len(query.Buckets)-1].Count != 1 {
+		t.Errorf("Expected query to be observed in latency histogram, got %+v", query.Buckets)
+	}
+
+	var decoded sqllogger.MetricsSnapshot
+	if err := json.Unmarshal([]byte(metricsLogger.String()), &decoded); err != nil {
+		t.Fatalf("Expected expvar string to be valid JSON: %v", err)
+	}
+	if decoded.Operations[sqllogger.KindExec].Count != 2 {
+		t.Errorf("Expected decoded exec count to be 2, got %d", decoded.Operations[sqllogger.KindExec].Count)
+	}
+}
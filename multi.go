@@ -0,0 +1,50 @@
+package sqllogger
+
+import (
+	"context"
+)
+
+// NewMultiLogger creates a SQLLogger that forwards every operation to all given loggers in order
+//
+// Failed operations are forwarded to all loggers implementing SQLErrorLogger. A panic in one logger is recovered, so
+// it neither affects the other loggers nor the database operation. Set OnPanic to get notified about recovered panics.
+func NewMultiLogger(loggers ...SQLLogger) *MultiLogger {
+	m := &MultiLogger{
+		loggers: loggers,
+	}
+	m.eventSQLLogger.l = m
+	return m
+}
+
+// MultiLogger is a SQLLogger forwarding operations to multiple loggers
+type MultiLogger struct {
+	eventSQLLogger
+
+	loggers []SQLLogger
+
+	// OnPanic is called if a logger panics
+	OnPanic PanicHandler
+}
+
+var _ SQLLogger = &MultiLogger{}
+var _ SQLErrorLogger = &MultiLogger{}
+
+// LogEvent satisfies EventLogger interface
+func (m *MultiLogger) LogEvent(ctx context.Context, ev Event) {
+	for _, log := range m.loggers {
+		dispatchRecover(ctx, ev, log, m.OnPanic)
+	}
+}
+
+// PanicHandler is called with the logger, the event and the recovered value if a logger panics
+type PanicHandler func(ctx context.Context, log SQLLogger, ev Event, recovered interface{})
+
+// dispatchRecover dispatches the event to the logger and recovers from a panic of the logger
+func dispatchRecover(ctx context.Context, ev Event, log SQLLogger, onPanic PanicHandler) {
+	defer func() {
+		if r := recover(); r != nil && onPanic != nil {
+			onPanic(ctx, log, ev, r)
+		}
+	}()
+	ev.Dispatch(ctx, log)
+}
@@ -0,0 +1,54 @@
+package sqllogger_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/networkteam/go-sqllogger"
+)
+
+func TestMultiLogger(t *testing.T) {
+	first := newTestLogger()
+	second := newTestLogger()
+	faulty := sqllogger.FromEventLogger(sqllogger.EventLoggerFunc(func(ctx context.Context, ev sqllogger.Event) {
+		panic("faulty logger")
+	}))
+
+	multiLogger := sqllogger.NewMultiLogger(first, faulty, second)
+	var panics int
+	multiLogger.OnPanic = func(ctx context.Context, log sqllogger.SQLLogger, ev sqllogger.Event, recovered interface{}) {
+		if log != faulty || recovered != "faulty logger" {
+			t.Errorf("Unexpected panic from %T: %v", log, recovered)
+		}
+		panics++
+	}
+
+	connector := new(fakeConnector)
+	db := sql.OpenDB(sqllogger.LoggingConnector(multiLogger, connector))
+	defer db.Close()
+
+	ctx := context.Background()
+	_, err := db.PrepareContext(ctx, "UNKNOWN|multi")
+	if err == nil {
+		t.Fatalf("Expected error from PrepareContext")
+	}
+
+	expectedLogs := []string{
+		`Connect`,
+		`OperationError(ConnPrepareContext)`,
+	}
+	for _, logger := range []*testLogger{first, second} {
+		if len(logger.logs) != len(expectedLogs) {
+			t.Fatalf("Expected %d log entries, got %d: %+v", len(expectedLogs), len(logger.logs), logger.logs)
+		}
+		for i, actualEntry := range logger.logs {
+			if actualEntry != expectedLogs[i] {
+				t.Errorf("Expected log entry %d to be %q, got %q", i, expectedLogs[i], actualEntry)
+			}
+		}
+	}
+	if panics != 2 {
+		t.Errorf("Expected 2 recovered panics, got %d", panics)
+	}
+}
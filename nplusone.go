@@ -0,0 +1,146 @@
+package sqllogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"sort"
+	"sync"
+)
+
+// NPlusOneOpts configures a NPlusOneDetector
+type NPlusOneOpts struct {
+	// Threshold is the number of executions of the same statement shape in a scope that is still acceptable, it
+	// defaults to 1 if not positive, so every statement shape executed more than once is reported
+	Threshold int
+	// MaxSamples is the number of argument samples that are kept per statement
+	MaxSamples int
+	// Report is called when a scope ends for each statement shape that was executed more than Threshold times
+	Report func(ctx context.Context, report NPlusOneReport)
+	// Logger is used to log reports, if set
+	Logger StdLogger
+}
+
+// NPlusOneReport describes a statement shape that was executed repeatedly in a single scope
+type NPlusOneReport struct {
+	Fingerprint string
+	// Query is the normalized query of the statement
+	Query string
+	// Count is the number of executions in the scope
+	Count int
+	// SampleArgs contains the arguments of the first executions
+	SampleArgs [][]driver.NamedValue
+}
+
+// NewNPlusOneDetector creates a SQLLogger that detects repeated execution of the same statement shape in a scope
+//
+// A scope is attached to a context with Scope (e.g. in a HTTP middleware for each request). Only operations that
+// receive a context (ConnQueryContext, ConnExecContext, StmtQueryContext and StmtExecContext) can be assigned to a scope.
+func NewNPlusOneDetector(opts NPlusOneOpts) *NPlusOneDetector {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 1
+	}
+	if opts.MaxSamples == 0 {
+		opts.MaxSamples = 3
+	}
+	d := &NPlusOneDetector{
+		opts: opts,
+	}
+	d.eventSQLLogger.l = d
+	return d
+}
+
+// NPlusOneDetector is a SQLLogger detecting N+1 query problems per scope
+type NPlusOneDetector struct {
+	eventSQLLogger
+
+	opts NPlusOneOpts
+}
+
+var _ SQLLogger = &NPlusOneDetector{}
+
+type nPlusOneScopeKey struct {
+	d *NPlusOneDetector
+}
+
+type nPlusOneScope struct {
+	mx         sync.Mutex
+	statements map[string]*NPlusOneReport
+}
+
+// Scope attaches a new scope to the context, all statements executed with the returned context are counted in this
+// scope. The returned function ends the scope and reports statements exceeding the threshold.
+func (d *NPlusOneDetector) Scope(ctx context.Context) (context.Context, func()) {
+	scope := &nPlusOneScope{
+		statements: make(map[string]*NPlusOneReport),
+	}
+	scopedCtx := context.WithValue(ctx, nPlusOneScopeKey{d: d}, scope)
+	return scopedCtx, func() {
+		d.report(scopedCtx, scope)
+	}
+}
+
+// LogEvent satisfies EventLogger interface
+func (d *NPlusOneDetector) LogEvent(ctx context.Context, ev Event) {
+	kind := ev.Op.Kind()
+	if kind != KindQuery && kind != KindExec {
+		return
+	}
+	scope, ok := ctx.Value(nPlusOneScopeKey{d: d}).(*nPlusOneScope)
+	if !ok {
+		return
+	}
+
+	normalized := NormalizeQuery(ev.Query)
+	fingerprint := fingerprintNormalized(normalized)
+
+	scope.mx.Lock()
+	defer scope.mx.Unlock()
+
+	report := scope.statements[fingerprint]
+	if report == nil {
+		report = &NPlusOneReport{
+			Fingerprint: fingerprint,
+			Query:       normalized,
+		}
+		scope.statements[fingerprint] = report
+	}
+	report.Count++
+	if len(report.SampleArgs) < d.opts.MaxSamples {
+		report.SampleArgs = append(report.SampleArgs, cloneNamedValues(ev.NamedValues()))
+	}
+}
+
+func (d *NPlusOneDetector) report(ctx context.Context, scope *nPlusOneScope) {
+	scope.mx.Lock()
+	var reports []NPlusOneReport
+	for _, report := range scope.statements {
+		if report.Count > d.opts.Threshold {
+			reports = append(reports, *report)
+		}
+	}
+	scope.statements = make(map[string]*NPlusOneReport)
+	scope.mx.Unlock()
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Count > reports[j].Count
+	})
+	for _, report := range reports {
+		if d.opts.Report != nil {
+			d.opts.Report(ctx, report)
+		}
+		if d.opts.Logger != nil {
+			d.opts.Logger.Printf("N+1 ► %d × %s (sample args: %v)", report.Count, report.Query, sampleValues(report.SampleArgs))
+		}
+	}
+}
+
+func sampleValues(samples [][]driver.NamedValue) [][]driver.Value {
+	values := make([][]driver.Value, len(samples))
+	for i, args := range samples {
+		values[i] = make([]driver.Value, len(args))
+		for j, nv := range args {
+			values[i][j] = nv.Value
+		}
+	}
+	return values
+}
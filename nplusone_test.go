@@ -0,0 +1,91 @@
+package sqllogger_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/networkteam/go-sqllogger"
+)
+
+type testStdLogger []string
+
+func (tl *testStdLogger) Printf(format string, args ...interface{}) {
+	*tl = append(*tl, fmt.Sprintf(format, args...))
+}
+
+func TestNPlusOneDetector(t *testing.T) {
+	var reports []sqllogger.NPlusOneReport
+	var logs testStdLogger
+	detector := sqllogger.NewNPlusOneDetector(sqllogger.NPlusOneOpts{
+		Threshold: 2,
+		Report: func(ctx context.Context, report sqllogger.NPlusOneReport) {
+			reports = append(reports, report)
+		},
+		Logger: &logs,
+	})
+	connector := new(fakeConnector)
+	loggingConnector := sqllogger.LoggingConnector(detector, connector)
+
+	db := sql.OpenDB(loggingConnector)
+	defer db.Close()
+
+	_, err := db.ExecContext(context.Background(), "CREATE|nplusone|id=int64,name=string")
+	if err != nil {
+		t.Fatalf("Unexpected error from ExecContext: %v", err)
+	}
+
+	ctx, done := detector.Scope(context.Background())
+	for i := 1; i <= 5; i++ {
+		_, err = db.ExecContext(ctx, "INSERT|nplusone|id=?,name=?", i, "test")
+		if err != nil {
+			t.Fatalf("Unexpected error from ExecContext: %v", err)
+		}
+	}
+	rows, err := db.QueryContext(ctx, "SELECT|nplusone|id|")
+	if err != nil {
+		t.Fatalf("Unexpected error from QueryContext: %v", err)
+	}
+	_ = rows.Close()
+	done()
+
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report, got %d: %+v", len(reports), reports)
+	}
+	report := reports[0]
+	if report.Count != 5 {
+		t.Errorf("Expected count of 5, got %d", report.Count)
+	}
+	if report.Query != "INSERT|nplusone|id=?,name=?" {
+		t.Errorf("Expected normalized query, got %q", report.Query)
+	}
+	if len(report.SampleArgs) != 3 || report.SampleArgs[0][0].Value != int64(1) {
+		t.Errorf("Expected 3 samples starting with first args, got %+v", report.SampleArgs)
+	}
+	if len(logs) != 1 {
+		t.Errorf("Expected 1 log entry, got %d", len(logs))
+	}
+}
+
+func TestNPlusOneDetector_DefaultThreshold(t *testing.T) {
+	var reports []sqllogger.NPlusOneReport
+	detector := sqllogger.NewNPlusOneDetector(sqllogger.NPlusOneOpts{
+		Report: func(ctx context.Context, report sqllogger.NPlusOneReport) {
+			reports = append(reports, report)
+		},
+	})
+
+	ctx, done := detector.Scope(context.Background())
+	detector.ConnExecContext(ctx, 1, "UPDATE users SET active = true WHERE id = 1", nil)
+	detector.ConnQueryContext(ctx, 1, 2, "SELECT * FROM posts WHERE user_id = 1", nil)
+	detector.ConnQueryContext(ctx, 1, 3, "SELECT * FROM posts WHERE user_id = 2", nil)
+	done()
+
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report, got %d: %+v", len(reports), reports)
+	}
+	if reports[0].Count != 2 || reports[0].Query != "SELECT * FROM posts WHERE user_id = ?" {
+		t.Errorf("Expected report of repeated select, got %+v", reports[0])
+	}
+}
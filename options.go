@@ -0,0 +1,109 @@
+package sqllogger
+
+import (
+	"context"
+	"time"
+)
+
+// Option configures a LoggingConnector
+type Option func(o *connectorOpts)
+
+type connectorOpts struct {
+	ids       IDGenerator
+	now       func() time.Time
+	kinds     []OperationKind
+	args      bool
+	logErrors bool
+	recover   bool
+	onPanic   PanicHandler
+	db        DatabaseInfo
+}
+
+// WithIDGenerator sets the generator for IDs of connections, statements, rows and transactions
+//
+// By default, all connectors share a process-wide sequence (see NewSequenceIDGenerator).
+func WithIDGenerator(ids IDGenerator) Option {
+	return func(o *connectorOpts) {
+		o.ids = ids
+	}
+}
+
+// WithClock sets the function to get the current time for timings (see GetTiming), time.Now is used by default
+func WithClock(now func() time.Time) Option {
+	return func(o *connectorOpts) {
+		o.now = now
+	}
+}
+
+// WithOperations only passes operations of the given kinds to the logger, all operations are passed by default
+func WithOperations(kinds ...OperationKind) Option {
+	return func(o *connectorOpts) {
+		o.kinds = kinds
+	}
+}
+
+// WithArgs sets whether arguments of queries are passed to the logger, they are passed by default
+//
+// Disabling arguments is a simple way to keep sensitive values out of logs, see NewRedactingLogger for fine-grained
+// control.
+func WithArgs(enabled bool) Option {
+	return func(o *connectorOpts) {
+		o.args = enabled
+	}
+}
+
+// WithErrorLogging sets whether failed operations are passed to a logger implementing SQLErrorLogger, they are passed
+// by default
+func WithErrorLogging(enabled bool) Option {
+	return func(o *connectorOpts) {
+		o.logErrors = enabled
+	}
+}
+
+// WithPanicRecovery recovers from panics of the logger, so a faulty logger cannot break database operations
+//
+// The optional handler is called with the recovered value.
+func WithPanicRecovery(onPanic PanicHandler) Option {
+	return func(o *connectorOpts) {
+		o.recover = true
+		o.onPanic = onPanic
+	}
+}
+
+// WithLabel sets a label for the connector that is passed to the logger with the context of every operation
+// (see GetDatabaseInfo), so multiple databases can share one logger
+func WithLabel(label string) Option {
+	return func(o *connectorOpts) {
+		o.db.Label = label
+	}
+}
+
+// wrap composes the logger with the wrappers needed for the options
+func (o connectorOpts) wrap(log SQLLogger) SQLLogger {
+	if o.recover {
+		inner := log
+		log = FromEventLogger(EventLoggerFunc(func(ctx context.Context, ev Event) {
+			dispatchRecover(ctx, ev, inner, o.onPanic)
+		}))
+	}
+	if !o.args {
+		log = newArgsStrippingLogger(log)
+	}
+	if len(o.kinds) > 0 {
+		log = NewFilteringLogger(log, OnlyKinds(o.kinds...))
+	}
+	if !o.logErrors {
+		// Hide the SQLErrorLogger implementation of the logger
+		log = struct{ SQLLogger }{log}
+	}
+	return log
+}
+
+// newArgsStrippingLogger creates a SQLLogger that forwards all operations to the given logger without arguments
+func newArgsStrippingLogger(log SQLLogger) SQLLogger {
+	return FromEventLogger(EventLoggerFunc(func(ctx context.Context, ev Event) {
+		ev.Args = nil
+		ev.NamedArgs = nil
+		ev.Dispatch(ctx, log)
+	}))
+}
@@ -0,0 +1,239 @@
+// Package oteladapter provides a SQLLogger implementation that records OpenTelemetry spans for intercepted operations.
+package oteladapter
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/networkteam/go-sqllogger"
+)
+
+const instrumentationName = "github.com/networkteam/go-sqllogger/oteladapter"
+
+// NewSQLLogger creates a SQLLogger that records spans with a tracer of the given provider
+//
+// If tp is nil, the global tracer provider is used. Since the SQLLogger is called after an operation completed,
+// spans are recorded with the start and end time of the operation Timing. The parent span is taken from the ctx
+// passed to the *Context methods.
+func NewSQLLogger(tp trace.TracerProvider, opts ...Opts) sqllogger.SQLLogger {
+	var o Opts
+	switch len(opts) {
+	case 0:
+		o = DefaultOpts()
+	case 1:
+		o = opts[0]
+	default:
+		panic("expected zero or one opts")
+	}
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return sqllogger.FromEventLogger(&spanRecorder{
+		tracer:    tp.Tracer(instrumentationName),
+		opts:      o,
+		txSpans:   make(map[int64]trace.Span),
+		rowsSpans: make(map[int64]trace.Span),
+	})
+}
+
+type Opts struct {
+	// DBSystem is set as db.system attribute on all spans (e.g. "postgresql")
+	DBSystem string
+	// RecordPrepare records spans for prepare operations
+	RecordPrepare bool
+	// RecordRows records spans for the iteration of rows from the end of a query until the rows are closed
+	RecordRows bool
+}
+
+func DefaultOpts() Opts {
+	return Opts{
+		RecordPrepare: true,
+		RecordRows:    true,
+	}
+}
+
+type spanRecorder struct {
+	tracer trace.Tracer
+	opts   Opts
+
+	mx        sync.Mutex
+	txSpans   map[int64]trace.Span
+	rowsSpans map[int64]trace.Span
+}
+
+func (r *spanRecorder) LogEvent(ctx context.Context, ev sqllogger.Event) {
+	kind := ev.Op.Kind()
+	switch kind {
+	case sqllogger.KindBegin:
+		r.startTx(ctx, ev)
+	case sqllogger.KindCommit, sqllogger.KindRollback:
+		r.endTx(ctx, ev)
+	case sqllogger.KindQuery, sqllogger.KindExec:
+		r.recordStatement(ctx, ev)
+	case sqllogger.KindPrepare:
+		if r.opts.RecordPrepare || ev.Err != nil {
+			r.recordStatement(ctx, ev)
+		}
+	case sqllogger.KindClose:
+		if ev.Op == sqllogger.OpRowsClose {
+			r.endRows(ctx, ev)
+		}
+	case sqllogger.KindConnect:
+		if ev.Err != nil {
+			r.recordStatement(ctx, ev)
+		}
+	}
+}
+
+func (r *spanRecorder) recordStatement(ctx context.Context, ev sqllogger.Event) {
+	timing, _ := sqllogger.GetTiming(ctx)
+	ctx = r.txContext(ctx)
+
+	_, span := r.tracer.Start(ctx, "sql."+string(ev.Op.Kind()),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(timing.Start),
+		trace.WithAttributes(r.attributes(ev)...),
+	)
+	setError(span, ev.Err)
+	span.End(trace.WithTimestamp(timing.End))
+
+	if ev.RowsID == 0 || ev.Err != nil || !r.opts.RecordRows {
+		return
+	}
+
+	// The rows span is a sibling of the query span, starting when the query returned
+	_, rowsSpan := r.tracer.Start(ctx, "sql.rows",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(timing.End),
+		trace.WithAttributes(r.attributes(sqllogger.Event{RowsID: ev.RowsID, Query: ev.Query})...),
+	)
+	r.mx.Lock()
+	r.rowsSpans[ev.RowsID] = rowsSpan
+	r.mx.Unlock()
+}
+
+// txContext returns the context with the span of the active transaction (see sqllogger.GetLineage) as parent, so
+// statements run inside a transaction are recorded as its children
+func (r *spanRecorder) txContext(ctx context.Context) context.Context {
+	lineage, ok := sqllogger.GetLineage(ctx)
+	if !ok || lineage.TxID == 0 {
+		return ctx
+	}
+
+	r.mx.Lock()
+	span, ok := r.txSpans[lineage.TxID]
+	r.mx.Unlock()
+	if !ok {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, span)
+}
+
+func (r *spanRecorder) endRows(ctx context.Context, ev sqllogger.Event) {
+	stats, hasStats := sqllogger.GetRowsStats(ctx)
+	// RowsClose is called before OperationError if closing failed, the span is ended with the error event
+	if ev.Err == nil && hasStats && stats.Err != nil {
+		return
+	}
+
+	r.mx.Lock()
+	span, ok := r.rowsSpans[ev.RowsID]
+	delete(r.rowsSpans, ev.RowsID)
+	r.mx.Unlock()
+	if !ok {
+		return
+	}
+
+	if hasStats {
+		span.SetAttributes(
+			attribute.Int64("db.response.returned_rows", stats.Rows),
+			attribute.Bool("db.sqllogger.rows_eof", stats.EOF),
+		)
+	}
+	setError(span, ev.Err)
+	timing, _ := sqllogger.GetTiming(ctx)
+	span.End(trace.WithTimestamp(timing.End))
+}
+
+func (r *spanRecorder) startTx(ctx context.Context, ev sqllogger.Event) {
+	timing, _ := sqllogger.GetTiming(ctx)
+
+	_, span := r.tracer.Start(ctx, "sql.tx",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(timing.Start),
+		trace.WithAttributes(r.attributes(ev)...),
+	)
+	if ev.Err != nil {
+		setError(span, ev.Err)
+		span.End(trace.WithTimestamp(timing.End))
+		return
+	}
+
+	r.mx.Lock()
+	r.txSpans[ev.TxID] = span
+	r.mx.Unlock()
+}
+
+func (r *spanRecorder) endTx(ctx context.Context, ev sqllogger.Event) {
+	r.mx.Lock()
+	span, ok := r.txSpans[ev.TxID]
+	delete(r.txSpans, ev.TxID)
+	r.mx.Unlock()
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(attribute.String("db.sqllogger.tx_end", string(ev.Op.Kind())))
+	setError(span, ev.Err)
+	timing, _ := sqllogger.GetTiming(ctx)
+	span.End(trace.WithTimestamp(timing.End))
+}
+
+func (r *spanRecorder) attributes(ev sqllogger.Event) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if r.opts.DBSystem != "" {
+		attrs = append(attrs, attribute.String("db.system", r.opts.DBSystem))
+	}
+	if ev.Query != "" {
+		attrs = append(attrs, attribute.String("db.statement", ev.Query))
+		if operation := sqlOperation(ev.Query); operation != "" {
+			attrs = append(attrs, attribute.String("db.operation", operation))
+		}
+	}
+	if ev.ConnID != 0 {
+		attrs = append(attrs, attribute.Int64("db.sqllogger.conn_id", ev.ConnID))
+	}
+	if ev.StmtID != 0 {
+		attrs = append(attrs, attribute.Int64("db.sqllogger.stmt_id", ev.StmtID))
+	}
+	if ev.RowsID != 0 {
+		attrs = append(attrs, attribute.Int64("db.sqllogger.rows_id", ev.RowsID))
+	}
+	if ev.TxID != 0 {
+		attrs = append(attrs, attribute.Int64("db.sqllogger.tx_id", ev.TxID))
+	}
+	return attrs
+}
+
+func setError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// sqlOperation returns the first keyword of the query in upper case (e.g. "SELECT")
+func sqlOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,171 @@
+package oteladapter_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/networkteam/go-sqllogger"
+	"github.com/networkteam/go-sqllogger/oteladapter"
+)
+
+func TestNewSQLLogger(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	sqlLogger := oteladapter.NewSQLLogger(tp, oteladapter.Opts{
+		DBSystem:   "postgresql",
+		RecordRows: true,
+	})
+
+	ctx, parentSpan := tp.Tracer("test").Start(context.Background(), "request")
+
+	start := time.Now()
+	timed := func(ctx context.Context, offset, d time.Duration) context.Context {
+		return sqllogger.WithTiming(ctx, sqllogger.Timing{Start: start.Add(offset), End: start.Add(offset + d)})
+	}
+
+	sqlLogger.ConnBegin(timed(ctx, 0, time.Millisecond), 1, 2, driver.TxOptions{})
+	sqlLogger.ConnQueryContext(timed(ctx, 2*time.Millisecond, 3*time.Millisecond), 1, 3, "SELECT * FROM users", nil)
+	sqlLogger.RowsClose(timed(context.Background(), 10*time.Millisecond, time.Millisecond), 3)
+	sqlLogger.(sqllogger.SQLErrorLogger).OperationError(timed(ctx, 12*time.Millisecond, time.Millisecond), sqllogger.Event{
+		Op:     sqllogger.OpConnExecContext,
+		ConnID: 1,
+		Query:  "update users set active = false",
+		Err:    errors.New("permission denied"),
+	})
+	sqlLogger.TxCommit(timed(context.Background(), 14*time.Millisecond, time.Millisecond), 2)
+
+	parentSpan.End()
+
+	spans := recorder.Ended()
+	expectedNames := []string{"sql.query", "sql.rows", "sql.exec", "sql.tx", "request"}
+	if len(spans) != len(expectedNames) {
+		t.Fatalf("expected %d spans, got %d", len(expectedNames), len(spans))
+	}
+	for i, span := range spans {
+		if span.Name() != expectedNames[i] {
+			t.Errorf("expected span %d to be %q, got %q", i, expectedNames[i], span.Name())
+		}
+		if span.Name() != "request" && span.Parent().SpanID() != parentSpan.SpanContext().SpanID() {
+			t.Errorf("expected span %q to have request span as parent", span.Name())
+		}
+	}
+
+	querySpan := spans[0]
+	if !querySpan.StartTime().Equal(start.Add(2*time.Millisecond)) || !querySpan.EndTime().Equal(start.Add(5*time.Millisecond)) {
+		t.Errorf("expected query span to use timing of operation, got %v - %v", querySpan.StartTime(), querySpan.EndTime())
+	}
+	assertAttribute(t, querySpan.Attributes(), attribute.String("db.statement", "SELECT * FROM users"))
+	assertAttribute(t, querySpan.Attributes(), attribute.String("db.operation", "SELECT"))
+	assertAttribute(t, querySpan.Attributes(), attribute.String("db.system", "postgresql"))
+
+	rowsSpan := spans[1]
+	if !rowsSpan.StartTime().Equal(start.Add(5*time.Millisecond)) || !rowsSpan.EndTime().Equal(start.Add(11*time.Millisecond)) {
+		t.Errorf("expected rows span to last from end of query until close, got %v - %v", rowsSpan.StartTime(), rowsSpan.EndTime())
+	}
+
+	execSpan := spans[2]
+	if execSpan.Status().Code != codes.Error {
+		t.Errorf("expected failed exec span to have error status, got %v", execSpan.Status())
+	}
+	assertAttribute(t, execSpan.Attributes(), attribute.String("db.operation", "UPDATE"))
+
+	txSpan := spans[3]
+	if !txSpan.StartTime().Equal(start) || !txSpan.EndTime().Equal(start.Add(15*time.Millisecond)) {
+		t.Errorf("expected tx span to last from begin until commit, got %v - %v", txSpan.StartTime(), txSpan.EndTime())
+	}
+}
+
+func assertAttribute(t *testing.T, attrs []attribute.KeyValue, expected attribute.KeyValue) {
+	t.Helper()
+
+	for _, attr := range attrs {
+		if attr.Key == expected.Key {
+			if attr.Value != expected.Value {
+				t.Errorf("expected attribute %s to be %v, got %v", expected.Key, expected.Value.Emit(), attr.Value.Emit())
+			}
+			return
+		}
+	}
+	t.Errorf("expected attribute %s to be set", expected.Key)
+}
+
+func TestNewSQLLogger_TxParent(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	sqlLogger := oteladapter.NewSQLLogger(tp, oteladapter.Opts{RecordRows: true})
+
+	ctx, parentSpan := tp.Tracer("test").Start(context.Background(), "request")
+	txCtx := sqllogger.WithLineage(ctx, sqllogger.Lineage{ConnID: 1, TxID: 2})
+
+	sqlLogger.ConnBegin(ctx, 1, 2, driver.TxOptions{})
+	sqlLogger.ConnExecContext(txCtx, 1, "UPDATE users SET active = false", nil)
+	sqlLogger.ConnQueryContext(txCtx, 1, 3, "SELECT * FROM users", nil)
+	sqlLogger.RowsClose(context.Background(), 3)
+	sqlLogger.TxCommit(ctx, 2)
+	sqlLogger.ConnExecContext(sqllogger.WithLineage(ctx, sqllogger.Lineage{ConnID: 1}), 1, "DELETE FROM sessions", nil)
+
+	parentSpan.End()
+
+	spans := recorder.Ended()
+	expectedNames := []string{"sql.exec", "sql.query", "sql.rows", "sql.tx", "sql.exec", "request"}
+	if len(spans) != len(expectedNames) {
+		t.Fatalf("expected %d spans, got %d", len(expectedNames), len(spans))
+	}
+	txSpanID := spans[3].SpanContext().SpanID()
+	for i, span := range spans[:3] {
+		if span.Name() != expectedNames[i] {
+			t.Errorf("expected span %d to be %q, got %q", i, expectedNames[i], span.Name())
+		}
+		if span.Parent().SpanID() != txSpanID {
+			t.Errorf("expected span %q to have tx span as parent", span.Name())
+		}
+	}
+	if spans[3].Parent().SpanID() != parentSpan.SpanContext().SpanID() {
+		t.Errorf("expected tx span to have request span as parent")
+	}
+	if spans[4].Parent().SpanID() != parentSpan.SpanContext().SpanID() {
+		t.Errorf("expected exec span after commit to have request span as parent")
+	}
+}
+
+func TestNewSQLLogger_RowsCloseError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	sqlLogger := oteladapter.NewSQLLogger(tp, oteladapter.Opts{RecordRows: true})
+
+	closeErr := errors.New("connection reset")
+	closeCtx := sqllogger.WithRowsStats(context.Background(), sqllogger.RowsStats{Rows: 2, Err: closeErr})
+
+	// Same order of calls as in the LoggingConnector if closing the rows failed
+	sqlLogger.ConnQueryContext(context.Background(), 1, 3, "SELECT * FROM users", nil)
+	sqlLogger.RowsClose(closeCtx, 3)
+	sqlLogger.(sqllogger.SQLErrorLogger).OperationError(closeCtx, sqllogger.Event{
+		Op:     sqllogger.OpRowsClose,
+		RowsID: 3,
+		Err:    closeErr,
+	})
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	rowsSpan := spans[1]
+	if rowsSpan.Name() != "sql.rows" {
+		t.Fatalf("expected rows span, got %q", rowsSpan.Name())
+	}
+	if rowsSpan.Status().Code != codes.Error {
+		t.Errorf("expected rows span to have error status, got %v", rowsSpan.Status())
+	}
+	assertAttribute(t, rowsSpan.Attributes(), attribute.Int64("db.response.returned_rows", 2))
+}
@@ -0,0 +1,96 @@
+// Package prometheusadapter provides a Prometheus collector for the metrics of a sqllogger.MetricsLogger.
+package prometheusadapter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/networkteam/go-sqllogger"
+)
+
+func NewCollector(m *sqllogger.MetricsLogger, opts ...Opts) *Collector {
+	var o Opts
+	switch len(opts) {
+	case 0:
+		o = DefaultOpts()
+	case 1:
+		o = opts[0]
+	default:
+		panic("expected zero or one opts")
+	}
+
+	desc := func(name, help string, variableLabels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(o.Namespace, "", name), help, variableLabels, o.ConstLabels)
+	}
+
+	return &Collector{
+		metricsLogger: m,
+
+		operations:       desc("operations_total", "Number of SQL operations by operation kind.", "operation"),
+		operationErrors:  desc("operation_errors_total", "Number of failed SQL operations by operation kind.", "operation"),
+		operationLatency: desc("operation_duration_seconds", "Duration of SQL operations by operation kind.", "operation"),
+		openConns:        desc("open_connections", "Number of open connections."),
+		openStmts:        desc("open_statements", "Number of open prepared statements."),
+		openRows:         desc("open_rows", "Number of open rows."),
+		openTxs:          desc("open_transactions", "Number of open transactions."),
+	}
+}
+
+// Collector is a prometheus.Collector exporting the metrics of a sqllogger.MetricsLogger
+type Collector struct {
+	metricsLogger *sqllogger.MetricsLogger
+
+	operations       *prometheus.Desc
+	operationErrors  *prometheus.Desc
+	operationLatency *prometheus.Desc
+	openConns        *prometheus.Desc
+	openStmts        *prometheus.Desc
+	openRows         *prometheus.Desc
+	openTxs          *prometheus.Desc
+}
+
+var _ prometheus.Collector = &Collector{}
+
+type Opts struct {
+	// Namespace is used as prefix for all metric names
+	Namespace string
+	// ConstLabels are added to all metrics (e.g. to distinguish multiple databases)
+	ConstLabels prometheus.Labels
+}
+
+func DefaultOpts() Opts {
+	return Opts{
+		Namespace: "sqllogger",
+	}
+}
+
+// Describe satisfies prometheus.Collector interface
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.operations
+	ch <- c.operationErrors
+	ch <- c.operationLatency
+	ch <- c.openConns
+	ch <- c.openStmts
+	ch <- c.openRows
+	ch <- c.openTxs
+}
+
+// Collect satisfies prometheus.Collector interface
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	snapshot := c.metricsLogger.Snapshot()
+
+	for kind, om := range snapshot.Operations {
+		ch <- prometheus.MustNewConstMetric(c.operations, prometheus.CounterValue, float64(om.Count), string(kind))
+		ch <- prometheus.MustNewConstMetric(c.operationErrors, prometheus.CounterValue, float64(om.Errors), string(kind))
+
+		buckets := make(map[float64]uint64, len(om.Buckets))
+		for _, bucket := range om.Buckets {
+			buckets[bucket.UpperBound.Seconds()] = uint64(bucket.Count)
+		}
+		ch <- prometheus.MustNewConstHistogram(c.operationLatency, uint64(om.Count), om.TotalDuration.Seconds(), buckets, string(kind))
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.openConns, prometheus.GaugeValue, float64(snapshot.OpenConns))
+	ch <- prometheus.MustNewConstMetric(c.openStmts, prometheus.GaugeValue, float64(snapshot.OpenStmts))
+	ch <- prometheus.MustNewConstMetric(c.openRows, prometheus.GaugeValue, float64(snapshot.OpenRows))
+	ch <- prometheus.MustNewConstMetric(c.openTxs, prometheus.GaugeValue, float64(snapshot.OpenTxs))
+}
@@ -0,0 +1,44 @@
+package prometheusadapter_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/networkteam/go-sqllogger"
+	"github.com/networkteam/go-sqllogger/prometheusadapter"
+)
+
+func TestNewCollector(t *testing.T) {
+	metricsLogger := sqllogger.NewMetricsLogger()
+
+	start := time.Now()
+	ctx := sqllogger.WithTiming(context.Background(), sqllogger.Timing{Start: start, End: start.Add(3 * time.Millisecond)})
+
+	metricsLogger.Connect(ctx, 1)
+	metricsLogger.ConnExecContext(ctx, 1, "UPDATE users SET active = false", nil)
+	metricsLogger.ConnExecContext(ctx, 1, "UPDATE users SET active = true", nil)
+
+	collector := prometheusadapter.NewCollector(metricsLogger)
+
+	expected := `
+# HELP sqllogger_open_connections Number of open connections.
+# TYPE sqllogger_open_connections gauge
+sqllogger_open_connections 1
+# HELP sqllogger_operations_total Number of SQL operations by operation kind.
+# TYPE sqllogger_operations_total counter
+sqllogger_operations_total{operation="connect"} 1
+sqllogger_operations_total{operation="exec"} 2
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "sqllogger_open_connections", "sqllogger_operations_total")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count := testutil.CollectAndCount(collector, "sqllogger_operation_duration_seconds"); count != 2 {
+		t.Errorf("expected 2 histograms, got %d", count)
+	}
+}
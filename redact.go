@@ -0,0 +1,363 @@
+package sqllogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DefaultRedactionMask is the value that replaces redacted arguments if no Mask is set
+const DefaultRedactionMask = "[REDACTED]"
+
+// Redactor replaces sensitive argument values with a mask before they are logged
+//
+// An argument is redacted if any of the configured rules matches. Names and columns are matched case-insensitive.
+// A Redactor must not be changed after it has been used.
+type Redactor struct {
+	// Mask replaces redacted values, DefaultRedactionMask is used if nil
+	Mask driver.Value
+
+	// Names redacts named arguments (driver.NamedValue.Name) like "password" or "token"
+	Names []string
+	// Positions redacts arguments by their ordinal position (starting at 1)
+	Positions []int
+	// Columns redacts arguments that are assigned to a column in INSERT column lists, UPDATE SET clauses or compared
+	// to a column in a condition (e.g. "password_hash = ?")
+	Columns []string
+	// Queries redacts all arguments of queries matching any of the patterns
+	Queries []*regexp.Regexp
+	// Values redacts arguments by their value, e.g. BytesLongerThan(1024)
+	Values []func(v driver.Value) bool
+
+	mx      sync.Mutex
+	columns map[string]placeholderColumns
+}
+
+// BytesLongerThan returns a value rule for Redactor.Values that matches byte slices longer than n bytes
+func BytesLongerThan(n int) func(v driver.Value) bool {
+	return func(v driver.Value) bool {
+		b, ok := v.([]byte)
+		return ok && len(b) > n
+	}
+}
+
+// NewRedactingLogger creates a SQLLogger that redacts arguments with the given Redactor before forwarding operations
+// to the given logger
+func NewRedactingLogger(log SQLLogger, r *Redactor) SQLLogger {
+	return FromEventLogger(EventLoggerFunc(func(ctx context.Context, ev Event) {
+		r.RedactEvent(ev).Dispatch(ctx, log)
+	}))
+}
+
+// RedactEvent returns the event with redacted Args and NamedArgs, the arguments of the original event are not modified
+func (r *Redactor) RedactEvent(ev Event) Event {
+	if ev.Args != nil {
+		var redacted []driver.Value
+		for i, v := range ev.Args {
+			if !r.matches(ev.Query, driver.NamedValue{Ordinal: i + 1, Value: v}) {
+				continue
+			}
+			if redacted == nil {
+				redacted = append([]driver.Value(nil), ev.Args...)
+			}
+			redacted[i] = r.mask()
+		}
+		if redacted != nil {
+			ev.Args = redacted
+		}
+	}
+	if ev.NamedArgs != nil {
+		ev.NamedArgs = r.RedactArgs(ev.Query, ev.NamedArgs)
+	}
+	return ev
+}
+
+// RedactArgs returns the arguments of the query with masked values for all arguments matching a rule
+//
+// The given slice is returned as is if no argument is redacted, otherwise a copy is returned.
+func (r *Redactor) RedactArgs(query string, args []driver.NamedValue) []driver.NamedValue {
+	var redacted []driver.NamedValue
+	for i, nv := range args {
+		if !r.matches(query, nv) {
+			continue
+		}
+		if redacted == nil {
+			redacted = append([]driver.NamedValue(nil), args...)
+		}
+		redacted[i].Value = r.mask()
+	}
+	if redacted == nil {
+		return args
+	}
+	return redacted
+}
+
+func (r *Redactor) mask() driver.Value {
+	if r.Mask == nil {
+		return DefaultRedactionMask
+	}
+	return r.Mask
+}
+
+func (r *Redactor) matches(query string, nv driver.NamedValue) bool {
+	if nv.Name != "" && containsFold(r.Names, nv.Name) {
+		return true
+	}
+	for _, pos := range r.Positions {
+		if nv.Ordinal == pos {
+			return true
+		}
+	}
+	for _, fn := range r.Values {
+		if fn(nv.Value) {
+			return true
+		}
+	}
+	for _, pattern := range r.Queries {
+		if pattern.MatchString(query) {
+			return true
+		}
+	}
+	if len(r.Columns) > 0 {
+		if column, ok := r.placeholderColumns(query).column(nv); ok && containsFold(r.Columns, column) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) placeholderColumns(query string) placeholderColumns {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if pc, ok := r.columns[query]; ok {
+		return pc
+	}
+	// Same limit as for fingerprints, queries with inlined literals could otherwise grow the cache without bounds
+	if r.columns == nil || len(r.columns) >= maxCachedFingerprints {
+		r.columns = make(map[string]placeholderColumns)
+	}
+	pc := parsePlaceholderColumns(query)
+	r.columns[query] = pc
+	return pc
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// placeholderColumns maps placeholders of a query to the column they are assigned to or compared with
+type placeholderColumns struct {
+	byOrdinal map[int]string
+	byName    map[string]string
+}
+
+func (pc placeholderColumns) column(nv driver.NamedValue) (string, bool) {
+	if nv.Name != "" {
+		if column, ok := pc.byName[strings.ToLower(nv.Name)]; ok {
+			return column, true
+		}
+	}
+	column, ok := pc.byOrdinal[nv.Ordinal]
+	return column, ok
+}
+
+type sqlTokenKind int
+
+const (
+	tokenOther sqlTokenKind = iota
+	tokenIdent
+	tokenPlaceholder
+	tokenOperator
+	tokenPunct
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	// text is the lower-cased identifier, operator or punctuation character
+	text    string
+	ordinal int
+	name    string
+}
+
+// tokenizeSQL splits a query into the tokens needed to map placeholders to columns, comments are skipped
+func tokenizeSQL(query string) []sqlToken {
+	var tokens []sqlToken
+	runes := []rune(query)
+	n := len(runes)
+	position := 0
+	identEnd := func(i int) int {
+		for i < n && isIdentRune(runes[i]) {
+			i++
+		}
+		return i
+	}
+
+	for i := 0; i < n; i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+		case r == '-' && i+1 < n && runes[i+1] == '-':
+			for i < n && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < n && runes[i+1] == '*':
+			i += 2
+			for i < n && !(runes[i] == '*' && i+1 < n && runes[i+1] == '/') {
+				i++
+			}
+			i++
+		case r == '\'':
+			i++
+			for i < n && runes[i] != '\'' {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: tokenOther})
+		case r == '"' || r == '`':
+			start := i + 1
+			i++
+			for i < n && runes[i] != r {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: tokenIdent, text: strings.ToLower(string(runes[start:min(i, n)]))})
+		case r == '?':
+			position++
+			tokens = append(tokens, sqlToken{kind: tokenPlaceholder, ordinal: position})
+		case r == '$' && i+1 < n && unicode.IsDigit(runes[i+1]):
+			end := identEnd(i + 1)
+			ordinal, _ := strconv.Atoi(string(runes[i+1 : end]))
+			tokens = append(tokens, sqlToken{kind: tokenPlaceholder, ordinal: ordinal})
+			i = end - 1
+		case (r == ':' || r == '@') && i+1 < n && unicode.IsLetter(runes[i+1]) && !(i > 0 && runes[i-1] == ':'):
+			end := identEnd(i + 1)
+			name := string(runes[i+1 : end])
+			tok := sqlToken{kind: tokenPlaceholder, name: strings.ToLower(name)}
+			if r == '@' && len(name) > 1 && (name[0] == 'p' || name[0] == 'P') {
+				tok.ordinal, _ = strconv.Atoi(name[1:])
+			}
+			tokens = append(tokens, tok)
+			i = end - 1
+		case isIdentRune(r):
+			end := identEnd(i)
+			kind := tokenIdent
+			if unicode.IsDigit(r) {
+				kind = tokenOther
+			}
+			tokens = append(tokens, sqlToken{kind: kind, text: strings.ToLower(string(runes[i:end]))})
+			i = end - 1
+		case r == '=' || r == '<' || r == '>' || r == '!':
+			end := i + 1
+			for end < n && (runes[end] == '=' || runes[end] == '>') {
+				end++
+			}
+			tokens = append(tokens, sqlToken{kind: tokenOperator, text: string(runes[i:end])})
+			i = end - 1
+		default:
+			tokens = append(tokens, sqlToken{kind: tokenPunct, text: string(r)})
+		}
+	}
+	return tokens
+}
+
+// parsePlaceholderColumns detects columns for placeholders in INSERT column lists and for placeholders that directly
+// follow a comparison or assignment to a column (e.g. "SET password = ?" or "WHERE token = $1")
+func parsePlaceholderColumns(query string) placeholderColumns {
+	pc := placeholderColumns{
+		byOrdinal: make(map[int]string),
+		byName:    make(map[string]string),
+	}
+	assign := func(tok sqlToken, column string) {
+		if tok.name != "" {
+			pc.byName[tok.name] = column
+		}
+		if tok.ordinal != 0 {
+			pc.byOrdinal[tok.ordinal] = column
+		}
+	}
+
+	tokens := tokenizeSQL(query)
+	for i, tok := range tokens {
+		if tok.kind == tokenPlaceholder && i >= 2 && tokens[i-1].kind == tokenOperator && tokens[i-2].kind == tokenIdent {
+			assign(tok, tokens[i-2].text)
+		}
+	}
+
+	if len(tokens) > 0 && tokens[0].text == "insert" {
+		parseInsertColumns(tokens, assign)
+	}
+
+	return pc
+}
+
+// parseInsertColumns assigns placeholders in VALUES tuples to the columns of the column list by their position
+func parseInsertColumns(tokens []sqlToken, assign func(tok sqlToken, column string)) {
+	var columns []string
+	i := 0
+	// Find the column list after the table name
+	for ; i < len(tokens); i++ {
+		if tokens[i].text == "values" || tokens[i].text == "select" {
+			return
+		}
+		if tokens[i].text == "(" && i > 0 && tokens[i-1].kind == tokenIdent {
+			break
+		}
+	}
+	for i++; i < len(tokens) && tokens[i].text != ")"; i++ {
+		if tokens[i].kind == tokenIdent {
+			columns = append(columns, tokens[i].text)
+		}
+	}
+	for ; i < len(tokens) && tokens[i].text != "values"; i++ {
+	}
+
+	// Assign placeholders that are the only token of a value in a tuple
+	depth, index, valueTokens := 0, 0, 0
+	var placeholder *sqlToken
+	endValue := func() {
+		if valueTokens == 1 && placeholder != nil && index < len(columns) {
+			assign(*placeholder, columns[index])
+		}
+		placeholder, valueTokens = nil, 0
+	}
+	for i++; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok.text == "(":
+			depth++
+			if depth == 1 {
+				index, valueTokens, placeholder = 0, 0, nil
+				continue
+			}
+		case tok.text == ")":
+			depth--
+			if depth == 0 {
+				endValue()
+				continue
+			}
+		case tok.text == "," && depth == 1:
+			endValue()
+			index++
+			continue
+		}
+		if depth == 0 {
+			if tok.text == "," {
+				continue
+			}
+			// End of VALUES clause (e.g. ON CONFLICT or RETURNING)
+			return
+		}
+		valueTokens++
+		if tok.kind == tokenPlaceholder {
+			placeholder = &tokens[i]
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package sqllogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestRedactor_RedactArgs(t *testing.T) {
+	r := &Redactor{
+		Names:     []string{"token"},
+		Positions: []int{4},
+		Columns:   []string{"password_hash", "ssn"},
+		Queries:   []*regexp.Regexp{regexp.MustCompile(`(?i)^INSERT INTO secrets\b`)},
+		Values:    []func(v driver.Value) bool{BytesLongerThan(3)},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		expected string
+	}{
+		{
+			name:     "insert columns",
+			query:    "INSERT INTO users (email, password_hash, avatar) VALUES (?, ?, ?), (lower(?), ?, ?) RETURNING id",
+			args:     []interface{}{"a@example.com", "xyz", []byte{1, 2, 3, 4}, "b@example.com", "abc", []byte{1}},
+			expected: "[a@example.com [REDACTED] [REDACTED] [REDACTED] [REDACTED] [1]]",
+		},
+		{
+			name:     "update set and where",
+			query:    `UPDATE users SET "SSN" = $2 WHERE id = $1 AND email <> $3`,
+			args:     []interface{}{int64(1), "123-45-6789", "a@example.com"},
+			expected: "[1 [REDACTED] a@example.com]",
+		},
+		{
+			name:     "named and position",
+			query:    "SELECT * FROM sessions WHERE token = :token AND user_id = :user_id AND a = :a AND b = :b",
+			args:     []interface{}{driver.NamedValue{Name: "token", Value: "t0k3n"}, int64(2), "a", "b"},
+			expected: "[[REDACTED] 2 a [REDACTED]]",
+		},
+		{
+			name:     "query pattern",
+			query:    "insert into secrets (k, v) values (?, ?)",
+			args:     []interface{}{"k", "v"},
+			expected: "[[REDACTED] [REDACTED]]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]driver.NamedValue, len(tt.args))
+			for i, v := range tt.args {
+				if nv, ok := v.(driver.NamedValue); ok {
+					args[i] = nv
+				} else {
+					args[i].Value = v
+				}
+				args[i].Ordinal = i + 1
+			}
+
+			redacted := r.RedactArgs(tt.query, args)
+
+			values := make([]driver.Value, len(redacted))
+			for i, nv := range redacted {
+				values[i] = nv.Value
+			}
+			if actual := fmt.Sprint(values); actual != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewRedactingLogger(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	defaultSQLLogger.InterpolateArgs = true
+	log := NewRedactingLogger(defaultSQLLogger, &Redactor{Columns: []string{"password"}, Mask: "***"})
+
+	args := []driver.Value{"ada", "secret"}
+	log.ConnExec(context.Background(), 1, "UPDATE users SET name = ?, password = ?", args)
+
+	expectedEntry := "CONN(1) ► Exec(UPDATE users SET name = 'ada', password = '***')"
+	if len(l) != 1 || l[0] != expectedEntry {
+		t.Errorf("expected log entry %q, but got %q", expectedEntry, l)
+	}
+	if args[1] != "secret" {
+		t.Errorf("expected original args to be unchanged, got %v", args)
+	}
+}
@@ -0,0 +1,69 @@
+package sqllogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+)
+
+// ExecResult contains the result of an exec operation as returned by the driver
+type ExecResult struct {
+	// RowsAffected is the number of rows affected by the exec operation
+	RowsAffected int64
+	// RowsAffectedErr is the error returned by driver.Result.RowsAffected, if any
+	RowsAffectedErr error
+	// LastInsertID is the last inserted id, only supported by some drivers
+	LastInsertID int64
+	// LastInsertIDErr is the error returned by driver.Result.LastInsertId, if any
+	LastInsertIDErr error
+}
+
+func newExecResult(res driver.Result) ExecResult {
+	var result ExecResult
+	if res == nil {
+		return result
+	}
+	result.RowsAffected, result.RowsAffectedErr = res.RowsAffected()
+	result.LastInsertID, result.LastInsertIDErr = res.LastInsertId()
+	return result
+}
+
+// lazyExecResult reads the values of a driver result on first use, LastInsertId can be costly for some drivers
+type lazyExecResult struct {
+	res    driver.Result
+	once   sync.Once
+	result ExecResult
+}
+
+func (l *lazyExecResult) get() ExecResult {
+	l.once.Do(func() {
+		l.result = newExecResult(l.res)
+	})
+	return l.result
+}
+
+type execResultKey struct{}
+
+// WithExecResult returns a new context with the given exec result, it is set by LoggingConnector for all exec operations
+func WithExecResult(ctx context.Context, result ExecResult) context.Context {
+	return context.WithValue(ctx, execResultKey{}, result)
+}
+
+// withDriverResult returns a new context with the result of the driver, its values are only read if requested with
+// GetExecResult
+func withDriverResult(ctx context.Context, res driver.Result) context.Context {
+	return context.WithValue(ctx, execResultKey{}, &lazyExecResult{res: res})
+}
+
+// GetExecResult returns the exec result from the context, if set
+//
+// The values of the driver result are read on the first call, so loggers not using the result add no overhead.
+func GetExecResult(ctx context.Context) (ExecResult, bool) {
+	switch result := ctx.Value(execResultKey{}).(type) {
+	case ExecResult:
+		return result, true
+	case *lazyExecResult:
+		return result.get(), true
+	}
+	return ExecResult{}, false
+}
@@ -0,0 +1,44 @@
+package sqllogger
+
+import (
+	"context"
+	"testing"
+)
+
+type countingResult struct {
+	calls int
+}
+
+func (r *countingResult) LastInsertId() (int64, error) {
+	r.calls++
+	return 42, nil
+}
+
+func (r *countingResult) RowsAffected() (int64, error) {
+	return 3, nil
+}
+
+func TestGetExecResult_Lazy(t *testing.T) {
+	res := new(countingResult)
+	ctx := withDriverResult(context.Background(), res)
+	if res.calls != 0 {
+		t.Fatalf("expected result not to be read before GetExecResult, got %d calls", res.calls)
+	}
+
+	for i := 0; i < 2; i++ {
+		result, ok := GetExecResult(ctx)
+		if !ok {
+			t.Fatalf("expected exec result in context")
+		}
+		if result.RowsAffected != 3 || result.LastInsertID != 42 {
+			t.Errorf("unexpected exec result: %+v", result)
+		}
+	}
+	if res.calls != 1 {
+		t.Errorf("expected LastInsertId to be called once, got %d calls", res.calls)
+	}
+
+	if _, ok := GetExecResult(context.Background()); ok {
+		t.Errorf("expected no exec result without value in context")
+	}
+}
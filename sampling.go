@@ -0,0 +1,155 @@
+package sqllogger
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// SamplingOpts configures NewSamplingLogger
+type SamplingOpts struct {
+	// Rate is the probability between 0 and 1 that an operation is forwarded, zero disables probabilistic sampling
+	Rate float64
+	// MaxPerFingerprint limits the number of forwarded operations per statement shape (see Fingerprint) and second,
+	// zero disables the rate limit. It only applies to operations with a query (prepare, query and exec).
+	MaxPerFingerprint int
+	// AlwaysLogSlowerThan forwards operations with a duration of at least the given value regardless of sampling and
+	// rate limits, zero disables the override. Operations flagged by NewSlowQueryLogger (see IsSlow) are always forwarded.
+	AlwaysLogSlowerThan time.Duration
+}
+
+// NewSamplingLogger creates a SQLLogger that only forwards a sample of operations to the given logger
+//
+// Failed operations are always forwarded if the wrapped logger implements SQLErrorLogger.
+// The number of dropped operations can be read with Stats.
+func NewSamplingLogger(log SQLLogger, opts SamplingOpts) *SamplingLogger {
+	s := &SamplingLogger{
+		log:          log,
+		opts:         opts,
+		fingerprints: make(map[string]string),
+		windows:      make(map[string]*rateWindow),
+		now:          time.Now,
+		random:       rand.Float64,
+	}
+	s.eventSQLLogger.l = s
+	return s
+}
+
+// SamplingLogger is a SQLLogger forwarding a sample of operations to another logger, it is safe for concurrent use
+type SamplingLogger struct {
+	eventSQLLogger
+
+	log  SQLLogger
+	opts SamplingOpts
+
+	mx           sync.Mutex
+	fingerprints map[string]string
+	windows      map[string]*rateWindow
+	stats        SamplingStats
+
+	now    func() time.Time
+	random func() float64
+}
+
+var _ SQLLogger = &SamplingLogger{}
+var _ SQLErrorLogger = &SamplingLogger{}
+
+// SamplingStats contains the counters of a SamplingLogger
+type SamplingStats struct {
+	// Forwarded is the number of operations forwarded to the wrapped logger
+	Forwarded int64
+	// Sampled is the number of operations dropped by probabilistic sampling
+	Sampled int64
+	// RateLimited is the number of operations dropped by the per fingerprint rate limit
+	RateLimited int64
+}
+
+// Dropped returns the total number of dropped operations
+func (st SamplingStats) Dropped() int64 {
+	return st.Sampled + st.RateLimited
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// LogEvent satisfies EventLogger interface
+func (s *SamplingLogger) LogEvent(ctx context.Context, ev Event) {
+	if !s.forward(ctx, ev) {
+		return
+	}
+	ev.Dispatch(ctx, s.log)
+}
+
+// Stats returns the current counters of forwarded and dropped operations
+func (s *SamplingLogger) Stats() SamplingStats {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.stats
+}
+
+func (s *SamplingLogger) forward(ctx context.Context, ev Event) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if ev.Err != nil || s.isSlow(ctx) {
+		s.stats.Forwarded++
+		return true
+	}
+	if s.opts.Rate > 0 && s.opts.Rate < 1 && s.random() >= s.opts.Rate {
+		s.stats.Sampled++
+		return false
+	}
+	if s.opts.MaxPerFingerprint > 0 && ev.Query != "" && !s.allow(ev.Query) {
+		s.stats.RateLimited++
+		return false
+	}
+	s.stats.Forwarded++
+	return true
+}
+
+func (s *SamplingLogger) isSlow(ctx context.Context) bool {
+	if IsSlow(ctx) {
+		return true
+	}
+	if s.opts.AlwaysLogSlowerThan <= 0 {
+		return false
+	}
+	timing, ok := GetTiming(ctx)
+	return ok && timing.Duration() >= s.opts.AlwaysLogSlowerThan
+}
+
+// allow counts the operation in the current one second window of the fingerprint and returns whether it is within
+// the limit
+func (s *SamplingLogger) allow(query string) bool {
+	fingerprint, ok := s.fingerprints[query]
+	if !ok {
+		if len(s.fingerprints) >= maxCachedFingerprints {
+			s.fingerprints = make(map[string]string)
+		}
+		fingerprint = Fingerprint(query)
+		s.fingerprints[query] = fingerprint
+	}
+
+	now := s.now()
+	w := s.windows[fingerprint]
+	if w == nil {
+		if len(s.windows) >= maxCachedFingerprints {
+			s.windows = make(map[string]*rateWindow)
+		}
+		w = &rateWindow{start: now}
+		s.windows[fingerprint] = w
+	}
+	if now.Sub(w.start) >= time.Second {
+		w.start = now
+		w.count = 0
+	}
+	if w.count >= s.opts.MaxPerFingerprint {
+		return false
+	}
+	w.count++
+	return true
+}
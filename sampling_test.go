@@ -0,0 +1,60 @@
+package sqllogger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSamplingLogger(t *testing.T) {
+	var l testLogger
+
+	s := NewSamplingLogger(NewDefaultSQLLogger(&l), SamplingOpts{
+		Rate:                0.5,
+		MaxPerFingerprint:   2,
+		AlwaysLogSlowerThan: time.Second,
+	})
+	now := time.Date(2024, 5, 14, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	samples := []float64{0.1, 0.9, 0.2, 0.3, 0.4}
+	s.random = func() float64 {
+		r := samples[0]
+		samples = samples[1:]
+		return r
+	}
+
+	ctx := context.Background()
+	s.ConnExecContext(ctx, 1, "DELETE FROM users WHERE id = 1", nil) // forwarded
+	s.ConnExecContext(ctx, 1, "DELETE FROM users WHERE id = 2", nil) // sampled
+	s.ConnExecContext(ctx, 1, "DELETE FROM users WHERE id = 3", nil) // forwarded
+	s.ConnExecContext(ctx, 1, "DELETE FROM users WHERE id = 4", nil) // rate limited
+
+	slowCtx := WithTiming(ctx, Timing{Start: now, End: now.Add(2 * time.Second)})
+	s.ConnExecContext(slowCtx, 1, "DELETE FROM users WHERE id = 5", nil) // slow
+	s.OperationError(ctx, Event{Op: OpConnExecContext, ConnID: 1, Query: "DELETE FROM users WHERE id = 6", Err: errors.New("boom")})
+
+	now = now.Add(time.Second)
+	s.ConnExecContext(ctx, 1, "DELETE FROM users WHERE id = 7", nil) // forwarded in next window
+
+	expectedEntries := []string{
+		"CONN(1) ► Exec(DELETE FROM users WHERE id = 1)",
+		"CONN(1) ► Exec(DELETE FROM users WHERE id = 3)",
+		"CONN(1) ► Exec(DELETE FROM users WHERE id = 5)",
+		"CONN(1) ► Exec(DELETE FROM users WHERE id = 6) ✗ boom",
+		"CONN(1) ► Exec(DELETE FROM users WHERE id = 7)",
+	}
+	if len(l) != len(expectedEntries) {
+		t.Fatalf("expected log entries %q, but got %q", expectedEntries, l)
+	}
+	for i, entry := range expectedEntries {
+		if l[i] != entry {
+			t.Errorf("expected log entry %d to be %q, but got %q", i, entry, l[i])
+		}
+	}
+
+	expectedStats := SamplingStats{Forwarded: 5, Sampled: 1, RateLimited: 1}
+	if stats := s.Stats(); stats != expectedStats {
+		t.Errorf("expected stats %+v, got %+v", expectedStats, stats)
+	}
+}
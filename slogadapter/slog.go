@@ -0,0 +1,224 @@
+// Package slogadapter provides a SQLLogger implementation for the log/slog package of the standard library.
+package slogadapter
+
+import (
+	"context"
+	"database/sql/driver"
+	"log/slog"
+
+	"github.com/networkteam/go-sqllogger"
+)
+
+func NewSQLLogger(l *slog.Logger, opts ...Opts) *SQLLogger {
+	var o Opts
+	switch len(opts) {
+	case 0:
+		o = DefaultOpts()
+	case 1:
+		o = opts[0]
+	default:
+		panic("expected zero or one opts")
+	}
+	return &SQLLogger{
+		slogLogger: l,
+		opts:       o,
+	}
+}
+
+type SQLLogger struct {
+	slogLogger *slog.Logger
+
+	opts Opts
+}
+
+var _ sqllogger.SQLLogger = SQLLogger{}
+var _ sqllogger.SQLErrorLogger = SQLLogger{}
+
+type Opts struct {
+	ConnectLevel slog.Level
+	PrepareLevel slog.Level
+	QueryLevel   slog.Level
+	ExecLevel    slog.Level
+	CloseLevel   slog.Level
+	TxLevel      slog.Level
+	ErrorLevel   slog.Level
+
+	// Limits truncates long queries and large argument values
+	Limits sqllogger.Limits
+}
+
+func DefaultOpts() Opts {
+	return Opts{
+		ConnectLevel: slog.LevelDebug,
+		PrepareLevel: slog.LevelDebug,
+		QueryLevel:   slog.LevelInfo,
+		ExecLevel:    slog.LevelInfo,
+		CloseLevel:   slog.LevelDebug,
+		TxLevel:      slog.LevelInfo,
+		ErrorLevel:   slog.LevelError,
+	}
+}
+
+// log logs the message with the given attributes and the duration of the operation, if available
+//
+// Nothing is logged if the level is not enabled for the logger.
+func (l SQLLogger) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if !l.slogLogger.Enabled(ctx, level) {
+		return
+	}
+	if timing, ok := sqllogger.GetTiming(ctx); ok {
+		attrs = append(attrs, slog.Duration("duration", timing.Duration()))
+	}
+	l.slogLogger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+func (l SQLLogger) Connect(ctx context.Context, connID int64) {
+	l.log(ctx, l.opts.ConnectLevel, "DB Connect",
+		slog.Int64("conn_id", connID),
+	)
+}
+
+func (l SQLLogger) ConnBegin(ctx context.Context, connID, txID int64, opts driver.TxOptions) {
+	l.log(ctx, l.opts.TxLevel, "CONN Begin",
+		slog.Int64("conn_id", connID),
+		slog.Int64("tx_id", txID),
+	)
+}
+
+func (l SQLLogger) ConnPrepare(ctx context.Context, connID, stmtID int64, query string) {
+	l.log(ctx, l.opts.PrepareLevel, "CONN Prepare",
+		slog.Int64("conn_id", connID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Int64("stmt_id", stmtID),
+	)
+}
+
+func (l SQLLogger) ConnPrepareContext(ctx context.Context, connID int64, stmtID int64, query string) {
+	l.log(ctx, l.opts.PrepareLevel, "CONN Prepare",
+		slog.Int64("conn_id", connID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Int64("stmt_id", stmtID),
+	)
+}
+
+func (l SQLLogger) ConnQuery(ctx context.Context, connID, rowsID int64, query string, args []driver.Value) {
+	l.log(ctx, l.opts.QueryLevel, "CONN Query",
+		slog.Int64("conn_id", connID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Any("args", l.opts.Limits.TruncateValues(args)),
+		slog.Int64("rows_id", rowsID),
+	)
+}
+
+func (l SQLLogger) ConnQueryContext(ctx context.Context, connID int64, rowsID int64, query string, args []driver.NamedValue) {
+	l.log(ctx, l.opts.QueryLevel, "CONN Query",
+		slog.Int64("conn_id", connID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Any("args", l.opts.Limits.TruncateArgs(args)),
+		slog.Int64("rows_id", rowsID),
+	)
+}
+
+func (l SQLLogger) ConnExec(ctx context.Context, connID int64, query string, args []driver.Value) {
+	l.log(ctx, l.opts.ExecLevel, "CONN Exec",
+		slog.Int64("conn_id", connID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Any("args", l.opts.Limits.TruncateValues(args)),
+	)
+}
+
+func (l SQLLogger) ConnExecContext(ctx context.Context, connID int64, query string, args []driver.NamedValue) {
+	l.log(ctx, l.opts.ExecLevel, "CONN Exec",
+		slog.Int64("conn_id", connID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Any("args", l.opts.Limits.TruncateArgs(args)),
+	)
+}
+
+func (l SQLLogger) ConnClose(ctx context.Context, connID int64) {
+	l.log(ctx, l.opts.CloseLevel, "CONN Close",
+		slog.Int64("conn_id", connID),
+	)
+}
+
+func (l SQLLogger) StmtExec(ctx context.Context, stmtID int64, query string, args []driver.Value) {
+	l.log(ctx, l.opts.ExecLevel, "STMT Exec",
+		slog.Int64("stmt_id", stmtID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Any("args", l.opts.Limits.TruncateValues(args)),
+	)
+}
+
+func (l SQLLogger) StmtExecContext(ctx context.Context, stmtID int64, query string, args []driver.NamedValue) {
+	l.log(ctx, l.opts.ExecLevel, "STMT Exec",
+		slog.Int64("stmt_id", stmtID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Any("args", l.opts.Limits.TruncateArgs(args)),
+	)
+}
+
+func (l SQLLogger) StmtQuery(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.Value) {
+	l.log(ctx, l.opts.QueryLevel, "STMT Query",
+		slog.Int64("stmt_id", stmtID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Any("args", l.opts.Limits.TruncateValues(args)),
+		slog.Int64("rows_id", rowsID),
+	)
+}
+
+func (l SQLLogger) StmtQueryContext(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.NamedValue) {
+	l.log(ctx, l.opts.QueryLevel, "STMT Query",
+		slog.Int64("stmt_id", stmtID),
+		slog.String("query", l.opts.Limits.TruncateQuery(query)),
+		slog.Any("args", l.opts.Limits.TruncateArgs(args)),
+		slog.Int64("rows_id", rowsID),
+	)
+}
+
+func (l SQLLogger) StmtClose(ctx context.Context, stmtID int64) {
+	l.log(ctx, l.opts.CloseLevel, "STMT Close",
+		slog.Int64("stmt_id", stmtID),
+	)
+}
+
+func (l SQLLogger) RowsClose(ctx context.Context, rowsID int64) {
+	l.log(ctx, l.opts.CloseLevel, "ROWS Close",
+		slog.Int64("rows_id", rowsID),
+	)
+}
+
+func (l SQLLogger) TxCommit(ctx context.Context, txID int64) {
+	l.log(ctx, l.opts.TxLevel, "TX Commit",
+		slog.Int64("tx_id", txID),
+	)
+}
+
+func (l SQLLogger) TxRollback(ctx context.Context, txID int64) {
+	l.log(ctx, l.opts.TxLevel, "TX Rollback",
+		slog.Int64("tx_id", txID),
+	)
+}
+
+func (l SQLLogger) OperationError(ctx context.Context, ev sqllogger.Event) {
+	attrs := []slog.Attr{slog.String("op", string(ev.Op))}
+	if ev.ConnID != 0 {
+		attrs = append(attrs, slog.Int64("conn_id", ev.ConnID))
+	}
+	if ev.StmtID != 0 {
+		attrs = append(attrs, slog.Int64("stmt_id", ev.StmtID))
+	}
+	if ev.RowsID != 0 {
+		attrs = append(attrs, slog.Int64("rows_id", ev.RowsID))
+	}
+	if ev.TxID != 0 {
+		attrs = append(attrs, slog.Int64("tx_id", ev.TxID))
+	}
+	if ev.Query != "" {
+		attrs = append(attrs,
+			slog.String("query", l.opts.Limits.TruncateQuery(ev.Query)),
+			slog.Any("args", l.opts.Limits.TruncateArgs(ev.NamedValues())),
+		)
+	}
+	attrs = append(attrs, slog.Any("error", ev.Err))
+	l.log(ctx, l.opts.ErrorLevel, "SQL Error", attrs...)
+}
@@ -0,0 +1,67 @@
+package slogadapter_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/networkteam/go-sqllogger"
+	"github.com/networkteam/go-sqllogger/slogadapter"
+)
+
+type traceIDKey struct{}
+
+// traceHandler adds the trace id from the context to records to check that the context is passed to the handler
+type traceHandler struct {
+	slog.Handler
+}
+
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if traceID, ok := ctx.Value(traceIDKey{}).(string); ok {
+		r.AddAttrs(slog.String("trace_id", traceID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestNewSQLLogger(t *testing.T) {
+	var out bytes.Buffer
+
+	logger := slog.New(traceHandler{slog.NewTextHandler(&out, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})})
+
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "abc")
+	start := time.Now()
+	timedCtx := sqllogger.WithTiming(ctx, sqllogger.Timing{Start: start, End: start.Add(1500 * time.Microsecond)})
+
+	sqlLogger := slogadapter.NewSQLLogger(logger)
+	sqlLogger.Connect(ctx, 42)
+	sqlLogger.ConnBegin(ctx, 42, 43, driver.TxOptions{})
+	sqlLogger.ConnQueryContext(timedCtx, 42, 44, "SELECT 1", nil)
+	sqlLogger.TxCommit(ctx, 43)
+	sqlLogger.ConnClose(ctx, 42)
+
+	actualLog := out.String()
+	expectedLogLines := []string{
+		`level=INFO msg="CONN Begin" conn_id=42 tx_id=43 trace_id=abc`,
+		`level=INFO msg="CONN Query" conn_id=42 query="SELECT 1" args=[] rows_id=44 duration=1.5ms trace_id=abc`,
+		`level=INFO msg="TX Commit" tx_id=43 trace_id=abc`,
+	}
+	for i, logLine := range expectedLogLines {
+		if !strings.Contains(actualLog, logLine) {
+			t.Fatalf("expected log line %d:\n%s\n, but got:\n%s\n", i, logLine, actualLog)
+		}
+	}
+	if strings.Contains(actualLog, "DB Connect") {
+		t.Errorf("expected connect not to be logged at default info level, but got:\n%s\n", actualLog)
+	}
+}
@@ -0,0 +1,72 @@
+package sqllogger
+
+import (
+	"context"
+	"time"
+)
+
+// SlowQueryOpts configures the thresholds of NewSlowQueryLogger
+type SlowQueryOpts struct {
+	// Threshold is the minimum duration for an operation to be considered slow, zero disables it so only kinds in
+	// KindThresholds are considered
+	Threshold time.Duration
+	// KindThresholds overrides Threshold for specific kinds of operations (e.g. KindExec, KindQuery), a zero duration
+	// excludes the kind
+	KindThresholds map[OperationKind]time.Duration
+	// ForwardAll forwards every operation to the wrapped logger and flags slow operations (see IsSlow) instead of
+	// dropping operations below the threshold
+	ForwardAll bool
+}
+
+// NewSlowQueryLogger creates a SQLLogger that only forwards operations to the given logger if their Timing exceeds the
+// threshold for the kind of operation
+//
+// Failed operations are always forwarded if the wrapped logger implements SQLErrorLogger.
+func NewSlowQueryLogger(log SQLLogger, opts SlowQueryOpts) SQLLogger {
+	return FromEventLogger(&slowQueryLogger{
+		log:  log,
+		opts: opts,
+	})
+}
+
+type slowQueryLogger struct {
+	log  SQLLogger
+	opts SlowQueryOpts
+}
+
+func (s *slowQueryLogger) LogEvent(ctx context.Context, ev Event) {
+	if ev.Err != nil {
+		ev.Dispatch(ctx, s.log)
+		return
+	}
+
+	if s.isSlow(ctx, ev.Op) {
+		ctx = context.WithValue(ctx, slowKey{}, true)
+	} else if !s.opts.ForwardAll {
+		return
+	}
+	ev.Dispatch(ctx, s.log)
+}
+
+func (s *slowQueryLogger) isSlow(ctx context.Context, op Operation) bool {
+	timing, ok := GetTiming(ctx)
+	if !ok {
+		return false
+	}
+	threshold, ok := s.opts.KindThresholds[op.Kind()]
+	if !ok {
+		threshold = s.opts.Threshold
+	}
+	if threshold <= 0 {
+		return false
+	}
+	return timing.Duration() >= threshold
+}
+
+type slowKey struct{}
+
+// IsSlow returns whether the operation was flagged as slow by a logger created with NewSlowQueryLogger
+func IsSlow(ctx context.Context) bool {
+	slow, _ := ctx.Value(slowKey{}).(bool)
+	return slow
+}
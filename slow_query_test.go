@@ -0,0 +1,116 @@
+package sqllogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSlowQueryLogger(t *testing.T) {
+	var events []Event
+	var slowFlags []bool
+	recorder := FromEventLogger(EventLoggerFunc(func(ctx context.Context, ev Event) {
+		events = append(events, ev)
+		slowFlags = append(slowFlags, IsSlow(ctx))
+	}))
+
+	slowLogger := NewSlowQueryLogger(recorder, SlowQueryOpts{
+		Threshold: 200 * time.Millisecond,
+		KindThresholds: map[OperationKind]time.Duration{
+			KindCommit: 50 * time.Millisecond,
+		},
+	})
+
+	withDuration := func(d time.Duration) context.Context {
+		start := time.Now()
+		return WithTiming(context.Background(), Timing{Start: start, End: start.Add(d)})
+	}
+
+	slowLogger.ConnExecContext(withDuration(10*time.Millisecond), 1, "UPDATE fast", nil)
+	slowLogger.ConnExecContext(withDuration(300*time.Millisecond), 1, "UPDATE slow", nil)
+	slowLogger.TxCommit(withDuration(100*time.Millisecond), 2)
+	slowLogger.ConnClose(context.Background(), 1)
+	slowLogger.(SQLErrorLogger).OperationError(withDuration(time.Millisecond), Event{Op: OpConnQueryContext, ConnID: 1, Err: errors.New("failed")})
+
+	expectedOps := []Operation{OpConnExecContext, OpTxCommit, OpConnQueryContext}
+	if len(events) != len(expectedOps) {
+		t.Fatalf("expected %d events, got %d: %+v", len(expectedOps), len(events), events)
+	}
+	for i, ev := range events {
+		if ev.Op != expectedOps[i] {
+			t.Errorf("expected event %d to be %s, got %s", i, expectedOps[i], ev.Op)
+		}
+	}
+	if events[0].Query != "UPDATE slow" {
+		t.Errorf("expected slow exec to be forwarded, got %q", events[0].Query)
+	}
+	if !slowFlags[0] || !slowFlags[1] {
+		t.Errorf("expected forwarded operations to be flagged as slow")
+	}
+}
+
+func TestNewSlowQueryLogger_KindThresholdsOnly(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	slowLogger := NewSlowQueryLogger(defaultSQLLogger, SlowQueryOpts{
+		KindThresholds: map[OperationKind]time.Duration{
+			KindQuery: 200 * time.Millisecond,
+		},
+	})
+
+	start := time.Now()
+	slowCtx := WithTiming(context.Background(), Timing{Start: start, End: start.Add(time.Second)})
+	slowLogger.Connect(slowCtx, 1)
+	slowLogger.ConnBegin(slowCtx, 1, 2, driver.TxOptions{})
+	slowLogger.ConnExecContext(slowCtx, 1, "UPDATE t SET a = 1", nil)
+	slowLogger.ConnQueryContext(WithTiming(context.Background(), Timing{Start: start, End: start.Add(time.Millisecond)}), 1, 3, "SELECT 1", nil)
+	slowLogger.ConnQueryContext(slowCtx, 1, 4, "SELECT 2", nil)
+	slowLogger.TxCommit(slowCtx, 2)
+	slowLogger.ConnClose(slowCtx, 1)
+
+	expectedEntries := []string{
+		"SLOW CONN(1) ► Query(SELECT 2) → ROWS(4)",
+	}
+
+	if len(l) != len(expectedEntries) {
+		t.Fatalf("expect %d log entries, but got %d: %v", len(expectedEntries), len(l), l)
+	}
+
+	for i, entry := range l {
+		if entry != expectedEntries[i] {
+			t.Errorf("log entry at index %d expected to be %q, but got %q", i, expectedEntries[i], entry)
+		}
+	}
+}
+
+func TestNewSlowQueryLogger_ForwardAll(t *testing.T) {
+	var l testLogger
+
+	defaultSQLLogger := NewDefaultSQLLogger(&l)
+	slowLogger := NewSlowQueryLogger(defaultSQLLogger, SlowQueryOpts{
+		Threshold:  200 * time.Millisecond,
+		ForwardAll: true,
+	})
+
+	start := time.Now()
+	slowLogger.ConnQueryContext(WithTiming(context.Background(), Timing{Start: start, End: start.Add(time.Millisecond)}), 1, 2, "SELECT 1", nil)
+	slowLogger.ConnQueryContext(WithTiming(context.Background(), Timing{Start: start, End: start.Add(time.Second)}), 1, 3, "SELECT 2", nil)
+
+	expectedEntries := []string{
+		"CONN(1) ► Query(SELECT 1) → ROWS(2)",
+		"SLOW CONN(1) ► Query(SELECT 2) → ROWS(3)",
+	}
+
+	if len(l) != len(expectedEntries) {
+		t.Fatalf("expect %d log entries, but got %d", len(expectedEntries), len(l))
+	}
+
+	for i, entry := range l {
+		if entry != expectedEntries[i] {
+			t.Errorf("log entry at index %d expected to be %q, but got %q", i, expectedEntries[i], entry)
+		}
+	}
+}
@@ -10,7 +10,8 @@ import (
 // With this interface, adapters can be implemented for any log framework. For the standard library log.Logger, a
 // DefaultSQLLogger is provided as a default implementation.
 //
-// All methods are only called if the original operation returned without an error.
+// All methods are only called if the original operation returned without an error (except the Close methods, which are
+// always called). Failed operations can be logged by also implementing SQLErrorLogger.
 type SQLLogger interface {
 	// Connect is called on DB connect with a generated connection id.
 	Connect(ctx context.Context, connID int64)
@@ -60,3 +61,13 @@ type SQLLogger interface {
 	// Note: ctx is only for sqllogger metadata since TxRollback does not receive a context.
 	TxRollback(ctx context.Context, txID int64)
 }
+
+// SQLErrorLogger is an optional interface for a SQLLogger to log operations that returned an error
+//
+// It is detected via type assertion on the SQLLogger passed to LoggingConnector.
+type SQLErrorLogger interface {
+	// OperationError is called if an intercepted operation returned an error. The event contains the operation, known IDs,
+	// query and arguments of the failed operation and the returned error in ev.Err.
+	// Note: driver.ErrSkip is not reported, since it is only used to signal a fallback to database/sql.
+	OperationError(ctx context.Context, ev Event)
+}
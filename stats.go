@@ -0,0 +1,178 @@
+package sqllogger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// NewStatsLogger creates a SQLLogger that aggregates statistics of query and exec operations per normalized statement
+//
+// Statements are grouped by the fingerprint of the normalized query (see NormalizeQuery), similar to
+// pg_stat_statements but from the application side.
+func NewStatsLogger() *StatsLogger {
+	s := &StatsLogger{
+		statements:   make(map[string]*statementStats),
+		fingerprints: make(map[string]normalizedQuery),
+	}
+	s.eventSQLLogger.l = s
+	return s
+}
+
+// StatsLogger is a SQLLogger collecting statement statistics, it is safe for concurrent use
+type StatsLogger struct {
+	eventSQLLogger
+
+	mx           sync.Mutex
+	statements   map[string]*statementStats
+	fingerprints map[string]normalizedQuery
+}
+
+var _ SQLLogger = &StatsLogger{}
+var _ SQLErrorLogger = &StatsLogger{}
+
+// maxCachedFingerprints limits the cache of fingerprints by original query, queries with inlined literals could
+// otherwise grow the cache without bounds
+const maxCachedFingerprints = 1000
+
+type normalizedQuery struct {
+	query       string
+	fingerprint string
+}
+
+type statementStats struct {
+	query     string
+	errors    int64
+	histogram latencyHistogram
+}
+
+// StatementStats contains the statistics of a normalized statement
+type StatementStats struct {
+	Fingerprint string
+	// Query is the normalized query of the statement
+	Query string
+	// Calls is the number of executions, including failed executions
+	Calls int64
+	// Errors is the number of failed executions
+	Errors    int64
+	TotalTime time.Duration
+	MinTime   time.Duration
+	MaxTime   time.Duration
+	// P95Time is an estimation of the 95th percentile of the execution time
+	P95Time time.Duration
+}
+
+// MeanTime returns the average execution time of the statement
+func (s StatementStats) MeanTime() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalTime / time.Duration(s.Calls)
+}
+
+// LogEvent satisfies EventLogger interface
+func (s *StatsLogger) LogEvent(ctx context.Context, ev Event) {
+	kind := ev.Op.Kind()
+	if kind != KindQuery && kind != KindExec {
+		return
+	}
+
+	var d time.Duration
+	if timing, ok := GetTiming(ctx); ok {
+		d = timing.Duration()
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	nq := s.normalize(ev.Query)
+	st := s.statements[nq.fingerprint]
+	if st == nil {
+		st = &statementStats{query: nq.query}
+		s.statements[nq.fingerprint] = st
+	}
+	if ev.Err != nil {
+		st.errors++
+	}
+	st.histogram.observe(d)
+}
+
+func (s *StatsLogger) normalize(query string) normalizedQuery {
+	if nq, ok := s.fingerprints[query]; ok {
+		return nq
+	}
+	if len(s.fingerprints) >= maxCachedFingerprints {
+		s.fingerprints = make(map[string]normalizedQuery)
+	}
+	normalized := NormalizeQuery(query)
+	nq := normalizedQuery{query: normalized, fingerprint: fingerprintNormalized(normalized)}
+	s.fingerprints[query] = nq
+	return nq
+}
+
+// Snapshot returns the statistics of all statements ordered by fingerprint
+func (s *StatsLogger) Snapshot() []StatementStats {
+	s.mx.Lock()
+	result := make([]StatementStats, 0, len(s.statements))
+	for fingerprint, st := range s.statements {
+		result = append(result, StatementStats{
+			Fingerprint: fingerprint,
+			Query:       st.query,
+			Calls:       st.histogram.count,
+			Errors:      st.errors,
+			TotalTime:   st.histogram.sum,
+			MinTime:     st.histogram.min,
+			MaxTime:     st.histogram.max,
+			P95Time:     st.histogram.quantile(0.95),
+		})
+	}
+	s.mx.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Fingerprint < result[j].Fingerprint
+	})
+	return result
+}
+
+// TopByTotalTime returns the n statements with the highest total execution time, all statements are returned if n <= 0
+func (s *StatsLogger) TopByTotalTime(n int) []StatementStats {
+	result := s.Snapshot()
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].TotalTime > result[j].TotalTime
+	})
+	if n > 0 && n < len(result) {
+		result = result[:n]
+	}
+	return result
+}
+
+// WriteReport writes a table of the n statements with the highest total execution time to w, all statements are
+// written if n <= 0
+func (s *StatsLogger) WriteReport(w io.Writer, n int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CALLS\tERRORS\tTOTAL\tMEAN\tP95\tMAX\tQUERY")
+	for _, st := range s.TopByTotalTime(n) {
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			st.Calls,
+			st.Errors,
+			formatDuration(st.TotalTime),
+			formatDuration(st.MeanTime()),
+			formatDuration(st.P95Time),
+			formatDuration(st.MaxTime),
+			st.Query,
+		)
+	}
+	return tw.Flush()
+}
+
+// Reset removes all collected statistics
+func (s *StatsLogger) Reset() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.statements = make(map[string]*statementStats)
+}
@@ -0,0 +1,76 @@
+package sqllogger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsLogger(t *testing.T) {
+	statsLogger := NewStatsLogger()
+
+	withDuration := func(d time.Duration) context.Context {
+		start := time.Now()
+		return WithTiming(context.Background(), Timing{Start: start, End: start.Add(d)})
+	}
+
+	for i := 1; i <= 20; i++ {
+		statsLogger.ConnQueryContext(withDuration(time.Duration(i)*time.Millisecond), 1, 2, "SELECT * FROM users WHERE id = $1", nil)
+	}
+	statsLogger.ConnExecContext(withDuration(500*time.Millisecond), 1, "UPDATE users SET name = 'a' WHERE id IN (1, 2)", nil)
+	statsLogger.ConnExecContext(withDuration(300*time.Millisecond), 1, "UPDATE users SET name = 'b' WHERE id IN (3)", nil)
+	statsLogger.OperationError(withDuration(time.Millisecond), Event{Op: OpConnExecContext, ConnID: 1, Query: "UPDATE users SET name = 'c' WHERE id IN (4)", Err: errors.New("failed")})
+	statsLogger.Connect(withDuration(time.Second), 1)
+
+	top := statsLogger.TopByTotalTime(1)
+	if len(top) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(top))
+	}
+	update := top[0]
+	if update.Query != "UPDATE users SET name = ? WHERE id IN (?)" {
+		t.Errorf("expected normalized update query, got %q", update.Query)
+	}
+	if update.Calls != 3 || update.Errors != 1 {
+		t.Errorf("expected 3 calls with 1 error, got %d calls with %d errors", update.Calls, update.Errors)
+	}
+	if update.TotalTime != 801*time.Millisecond || update.MinTime != time.Millisecond || update.MaxTime != 500*time.Millisecond {
+		t.Errorf("unexpected times of update statement: %+v", update)
+	}
+
+	if all := statsLogger.TopByTotalTime(-1); len(all) != 2 {
+		t.Errorf("expected all 2 statements for negative n, got %d", len(all))
+	}
+
+	snapshot := statsLogger.Snapshot()
+	if len(snapshot) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(snapshot))
+	}
+	for _, st := range snapshot {
+		if st.Query != "SELECT * FROM users WHERE id = ?" {
+			continue
+		}
+		if st.Calls != 20 || st.MeanTime() != 10500*time.Microsecond {
+			t.Errorf("unexpected stats of select statement: %+v", st)
+		}
+		if st.P95Time < 10*time.Millisecond || st.P95Time > 25*time.Millisecond {
+			t.Errorf("expected p95 of select statement to be estimated inside the matching bucket, got %v", st.P95Time)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := statsLogger.WriteReport(&out, 10); err != nil {
+		t.Fatalf("unexpected error from WriteReport: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[1], "UPDATE users SET name = ? WHERE id IN (?)") {
+		t.Errorf("unexpected report:\n%s", out.String())
+	}
+
+	statsLogger.Reset()
+	if len(statsLogger.Snapshot()) != 0 {
+		t.Errorf("expected no statements after reset")
+	}
+}
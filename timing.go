@@ -5,17 +5,25 @@ import (
 	"time"
 )
 
+// Timing contains the start and end time of an intercepted operation
 type Timing struct {
 	Start time.Time
 	End   time.Time
 }
 
+// Duration returns the elapsed time between start and end of the operation
+func (t Timing) Duration() time.Duration {
+	return t.End.Sub(t.Start)
+}
+
 type timingKey struct{}
 
+// WithTiming returns a new context with the given timing, it is set by LoggingConnector for all SQLLogger calls
 func WithTiming(ctx context.Context, timing Timing) context.Context {
 	return context.WithValue(ctx, timingKey{}, timing)
 }
 
+// GetTiming returns the timing of the operation from the context, if set
 func GetTiming(ctx context.Context) (Timing, bool) {
 	timing := ctx.Value(timingKey{})
 	if timing == nil {
@@ -23,3 +31,28 @@ func GetTiming(ctx context.Context) (Timing, bool) {
 	}
 	return timing.(Timing), true
 }
+
+// RowsStats contains statistics about the iteration of rows
+type RowsStats struct {
+	// Rows is the number of rows returned by Next
+	Rows int64
+	// Timing spans from the start of the query until the rows have been closed
+	Timing Timing
+	// EOF is true if all rows have been read, false if the rows were closed early
+	EOF bool
+	// Err is the error of closing the rows, it is passed to OperationError after RowsClose
+	Err error
+}
+
+type rowsStatsKey struct{}
+
+// WithRowsStats returns a new context with the given rows stats, it is set by LoggingConnector for RowsClose
+func WithRowsStats(ctx context.Context, stats RowsStats) context.Context {
+	return context.WithValue(ctx, rowsStatsKey{}, stats)
+}
+
+// GetRowsStats returns the rows stats from the context, if set
+func GetRowsStats(ctx context.Context) (RowsStats, bool) {
+	stats, ok := ctx.Value(rowsStatsKey{}).(RowsStats)
+	return stats, ok
+}
@@ -0,0 +1,166 @@
+package sqllogger
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits restricts the size of logged queries and arguments, so all loggers truncate them in the same way
+//
+// A zero value for a limit disables it. Truncated parts are replaced by a "…(+N more)" marker.
+type Limits struct {
+	// MaxQueryLength is the maximum number of characters of a query
+	MaxQueryLength int
+	// MaxArgs is the maximum number of arguments
+	MaxArgs int
+	// MaxArgBytes is the maximum size of a single string or []byte argument in bytes
+	MaxArgBytes int
+}
+
+// truncationMarker returns the marker for n omitted characters, bytes or arguments
+func truncationMarker(n int) string {
+	return fmt.Sprintf("…(+%d more)", n)
+}
+
+// TruncateQuery returns the query shortened to MaxQueryLength characters
+func (l Limits) TruncateQuery(query string) string {
+	if l.MaxQueryLength <= 0 || len(query) <= l.MaxQueryLength {
+		return query
+	}
+	count := utf8.RuneCountInString(query)
+	if count <= l.MaxQueryLength {
+		return query
+	}
+	i, n := 0, 0
+	for i = range query {
+		if n == l.MaxQueryLength {
+			break
+		}
+		n++
+	}
+	return query[:i] + truncationMarker(count-l.MaxQueryLength)
+}
+
+// Interpolate returns the query interpolated with the truncated arguments (see Dialect.Interpolate) shortened to
+// MaxQueryLength
+//
+// If arguments are omitted because of MaxArgs, their placeholders are kept and a marker is appended to the query.
+func (l Limits) Interpolate(d Dialect, query string, args []driver.NamedValue) string {
+	omitted := 0
+	if l.MaxArgs > 0 && len(args) > l.MaxArgs {
+		omitted = len(args) - l.MaxArgs
+	}
+	query = l.TruncateQuery(d.Interpolate(query, l.TruncateArgs(args)))
+	if omitted > 0 {
+		query += " " + truncationMarker(omitted)
+	}
+	return query
+}
+
+// TruncateArgs returns at most MaxArgs arguments with values truncated to MaxArgBytes
+//
+// If arguments are omitted, a last argument with a marker as value and no ordinal is added. The given slice is
+// returned as is if no limit applies, otherwise a copy is returned.
+func (l Limits) TruncateArgs(args []driver.NamedValue) []driver.NamedValue {
+	if l.MaxArgs <= 0 && l.MaxArgBytes <= 0 {
+		return args
+	}
+	omitted := 0
+	if l.MaxArgs > 0 && len(args) > l.MaxArgs {
+		omitted = len(args) - l.MaxArgs
+		args = args[:l.MaxArgs]
+	}
+	var truncated []driver.NamedValue
+	for i, nv := range args {
+		v, ok := l.truncateValue(nv.Value)
+		if !ok {
+			continue
+		}
+		if truncated == nil {
+			truncated = make([]driver.NamedValue, len(args), len(args)+1)
+			copy(truncated, args)
+		}
+		truncated[i].Value = v
+	}
+	if omitted == 0 {
+		if truncated == nil {
+			return args
+		}
+		return truncated
+	}
+	if truncated == nil {
+		truncated = make([]driver.NamedValue, len(args), len(args)+1)
+		copy(truncated, args)
+	}
+	return append(truncated, driver.NamedValue{Value: truncationMarker(omitted)})
+}
+
+// TruncateValues returns at most MaxArgs values truncated to MaxArgBytes, see TruncateArgs
+func (l Limits) TruncateValues(args []driver.Value) []driver.Value {
+	if l.MaxArgs <= 0 && l.MaxArgBytes <= 0 {
+		return args
+	}
+	omitted := 0
+	if l.MaxArgs > 0 && len(args) > l.MaxArgs {
+		omitted = len(args) - l.MaxArgs
+		args = args[:l.MaxArgs]
+	}
+	var truncated []driver.Value
+	for i, v := range args {
+		v, ok := l.truncateValue(v)
+		if !ok {
+			continue
+		}
+		if truncated == nil {
+			truncated = make([]driver.Value, len(args), len(args)+1)
+			copy(truncated, args)
+		}
+		truncated[i] = v
+	}
+	if omitted == 0 {
+		if truncated == nil {
+			return args
+		}
+		return truncated
+	}
+	if truncated == nil {
+		truncated = make([]driver.Value, len(args), len(args)+1)
+		copy(truncated, args)
+	}
+	return append(truncated, truncationMarker(omitted))
+}
+
+// TruncateValue returns the value truncated to MaxArgBytes
+//
+// Strings are cut at a valid UTF-8 boundary, byte slices are converted to a hex string after truncation.
+func (l Limits) TruncateValue(v driver.Value) driver.Value {
+	if truncated, ok := l.truncateValue(v); ok {
+		return truncated
+	}
+	return v
+}
+
+func (l Limits) truncateValue(v driver.Value) (driver.Value, bool) {
+	if l.MaxArgBytes <= 0 {
+		return nil, false
+	}
+	switch v := v.(type) {
+	case string:
+		if len(v) <= l.MaxArgBytes {
+			return nil, false
+		}
+		end := l.MaxArgBytes
+		for end > 0 && !utf8.RuneStart(v[end]) {
+			end--
+		}
+		return v[:end] + truncationMarker(len(v)-end), true
+	case []byte:
+		if len(v) <= l.MaxArgBytes {
+			return nil, false
+		}
+		return hex.EncodeToString(v[:l.MaxArgBytes]) + truncationMarker(len(v)-l.MaxArgBytes), true
+	}
+	return nil, false
+}
@@ -0,0 +1,47 @@
+package sqllogger
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+)
+
+func TestLimits_TruncateQuery(t *testing.T) {
+	limits := Limits{MaxQueryLength: 20}
+
+	actual := limits.TruncateQuery("INSERT INTO äpfel (id) VALUES (1), (2), (3)")
+	expected := "INSERT INTO äpfel (i…(+23 more)"
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	short := "SELECT 1"
+	if actual := limits.TruncateQuery(short); actual != short {
+		t.Errorf("expected %q, got %q", short, actual)
+	}
+}
+
+func TestLimits_TruncateArgs(t *testing.T) {
+	limits := Limits{MaxArgs: 3, MaxArgBytes: 4}
+
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: int64(1)},
+		{Ordinal: 2, Value: "ääää"},
+		{Ordinal: 3, Value: []byte{0xca, 0xfe, 0xba, 0xbe, 0x00}},
+		{Ordinal: 4, Value: "x"},
+		{Ordinal: 5, Value: "y"},
+	}
+	actual := limits.TruncateArgs(args)
+	expected := "[{ 1 1} { 2 ää…(+4 more)} { 3 cafebabe…(+1 more)} { 0 …(+2 more)}]"
+	if fmt.Sprint(actual) != expected {
+		t.Errorf("expected %s, got %s", expected, fmt.Sprint(actual))
+	}
+	if args[1].Value != "ääää" {
+		t.Errorf("expected original args to be unchanged, got %v", args)
+	}
+
+	values := limits.TruncateValues([]driver.Value{"abc", "abcdef"})
+	if expected := "[abc abcd…(+2 more)]"; fmt.Sprint(values) != expected {
+		t.Errorf("expected %s, got %s", expected, fmt.Sprint(values))
+	}
+}
@@ -0,0 +1,93 @@
+package zapadapter
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// values encodes arguments without reflection for the common driver.Value types
+type values []driver.Value
+
+func (a values) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, v := range a {
+		appendValue(enc, v)
+	}
+	return nil
+}
+
+// namedValues encodes arguments like values, named arguments are encoded as an object with name and value
+type namedValues []driver.NamedValue
+
+func (a namedValues) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, nv := range a {
+		if nv.Name != "" {
+			if err := enc.AppendObject(namedValue(nv)); err != nil {
+				return err
+			}
+			continue
+		}
+		appendValue(enc, nv.Value)
+	}
+	return nil
+}
+
+type namedValue driver.NamedValue
+
+func (nv namedValue) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", nv.Name)
+	switch v := nv.Value.(type) {
+	case int64:
+		enc.AddInt64("value", v)
+	case float64:
+		enc.AddFloat64("value", v)
+	case bool:
+		enc.AddBool("value", v)
+	case string:
+		enc.AddString("value", v)
+	case []byte:
+		enc.AddByteString("value", v)
+	case time.Time:
+		enc.AddTime("value", v)
+	default:
+		return enc.AddReflected("value", v)
+	}
+	return nil
+}
+
+func appendValue(enc zapcore.ArrayEncoder, v driver.Value) {
+	switch v := v.(type) {
+	case int64:
+		enc.AppendInt64(v)
+	case float64:
+		enc.AppendFloat64(v)
+	case bool:
+		enc.AppendBool(v)
+	case string:
+		enc.AppendString(v)
+	case []byte:
+		enc.AppendByteString(v)
+	case time.Time:
+		enc.AppendTime(v)
+	default:
+		_ = enc.AppendReflected(v)
+	}
+}
+
+// valuesField encodes the arguments as an array, nil arguments are logged as null
+func valuesField(args []driver.Value) zap.Field {
+	if args == nil {
+		return zap.Reflect("args", nil)
+	}
+	return zap.Array("args", values(args))
+}
+
+// namedValuesField encodes the arguments as an array, nil arguments are logged as null
+func namedValuesField(args []driver.NamedValue) zap.Field {
+	if args == nil {
+		return zap.Reflect("args", nil)
+	}
+	return zap.Array("args", namedValues(args))
+}
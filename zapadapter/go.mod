@@ -0,0 +1,11 @@
+module github.com/networkteam/go-sqllogger/zapadapter
+
+go 1.23.0
+
+require (
+	github.com/networkteam/go-sqllogger v0.2.0
+	go.uber.org/zap v1.27.1
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
+
@@ -0,0 +1,279 @@
+// Package zapadapter provides a SQLLogger implementation for go.uber.org/zap.
+package zapadapter
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/networkteam/go-sqllogger"
+)
+
+func NewSQLLogger(l *zap.Logger, opts ...Opts) *SQLLogger {
+	var o Opts
+	switch len(opts) {
+	case 0:
+		o = DefaultOpts()
+	case 1:
+		o = opts[0]
+	default:
+		panic("expected zero or one opts")
+	}
+	return &SQLLogger{
+		zapLogger: l,
+		opts:      o,
+	}
+}
+
+// SQLLogger logs to a *zap.Logger
+//
+// Entries are checked against the configured level before any field is constructed, so disabled operations do not allocate.
+type SQLLogger struct {
+	zapLogger *zap.Logger
+
+	opts Opts
+}
+
+var _ sqllogger.SQLLogger = SQLLogger{}
+var _ sqllogger.SQLErrorLogger = SQLLogger{}
+
+type Opts struct {
+	ConnectLevel zapcore.Level
+	PrepareLevel zapcore.Level
+	QueryLevel   zapcore.Level
+	ExecLevel    zapcore.Level
+	CloseLevel   zapcore.Level
+	TxLevel      zapcore.Level
+	ErrorLevel   zapcore.Level
+
+	// Limits truncates long queries and large argument values
+	Limits sqllogger.Limits
+}
+
+func DefaultOpts() Opts {
+	return Opts{
+		ConnectLevel: zapcore.DebugLevel,
+		PrepareLevel: zapcore.DebugLevel,
+		QueryLevel:   zapcore.InfoLevel,
+		ExecLevel:    zapcore.InfoLevel,
+		CloseLevel:   zapcore.DebugLevel,
+		TxLevel:      zapcore.InfoLevel,
+		ErrorLevel:   zapcore.ErrorLevel,
+	}
+}
+
+// duration returns a field with the duration of the operation or a no-op field if no timing is available
+func duration(ctx context.Context) zap.Field {
+	if timing, ok := sqllogger.GetTiming(ctx); ok {
+		return zap.Duration("duration", timing.Duration())
+	}
+	return zap.Skip()
+}
+
+func (l SQLLogger) Connect(ctx context.Context, connID int64) {
+	if ce := l.zapLogger.Check(l.opts.ConnectLevel, "DB Connect"); ce != nil {
+		ce.Write(
+			zap.Int64("conn_id", connID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) ConnBegin(ctx context.Context, connID, txID int64, opts driver.TxOptions) {
+	if ce := l.zapLogger.Check(l.opts.TxLevel, "CONN Begin"); ce != nil {
+		ce.Write(
+			zap.Int64("conn_id", connID),
+			zap.Int64("tx_id", txID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) ConnPrepare(ctx context.Context, connID, stmtID int64, query string) {
+	if ce := l.zapLogger.Check(l.opts.PrepareLevel, "CONN Prepare"); ce != nil {
+		ce.Write(
+			zap.Int64("conn_id", connID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			zap.Int64("stmt_id", stmtID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) ConnPrepareContext(ctx context.Context, connID int64, stmtID int64, query string) {
+	if ce := l.zapLogger.Check(l.opts.PrepareLevel, "CONN Prepare"); ce != nil {
+		ce.Write(
+			zap.Int64("conn_id", connID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			zap.Int64("stmt_id", stmtID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) ConnQuery(ctx context.Context, connID, rowsID int64, query string, args []driver.Value) {
+	if ce := l.zapLogger.Check(l.opts.QueryLevel, "CONN Query"); ce != nil {
+		ce.Write(
+			zap.Int64("conn_id", connID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			valuesField(l.opts.Limits.TruncateValues(args)),
+			zap.Int64("rows_id", rowsID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) ConnQueryContext(ctx context.Context, connID int64, rowsID int64, query string, args []driver.NamedValue) {
+	if ce := l.zapLogger.Check(l.opts.QueryLevel, "CONN Query"); ce != nil {
+		ce.Write(
+			zap.Int64("conn_id", connID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			namedValuesField(l.opts.Limits.TruncateArgs(args)),
+			zap.Int64("rows_id", rowsID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) ConnExec(ctx context.Context, connID int64, query string, args []driver.Value) {
+	if ce := l.zapLogger.Check(l.opts.ExecLevel, "CONN Exec"); ce != nil {
+		ce.Write(
+			zap.Int64("conn_id", connID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			valuesField(l.opts.Limits.TruncateValues(args)),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) ConnExecContext(ctx context.Context, connID int64, query string, args []driver.NamedValue) {
+	if ce := l.zapLogger.Check(l.opts.ExecLevel, "CONN Exec"); ce != nil {
+		ce.Write(
+			zap.Int64("conn_id", connID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			namedValuesField(l.opts.Limits.TruncateArgs(args)),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) ConnClose(ctx context.Context, connID int64) {
+	if ce := l.zapLogger.Check(l.opts.CloseLevel, "CONN Close"); ce != nil {
+		ce.Write(
+			zap.Int64("conn_id", connID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) StmtExec(ctx context.Context, stmtID int64, query string, args []driver.Value) {
+	if ce := l.zapLogger.Check(l.opts.ExecLevel, "STMT Exec"); ce != nil {
+		ce.Write(
+			zap.Int64("stmt_id", stmtID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			valuesField(l.opts.Limits.TruncateValues(args)),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) StmtExecContext(ctx context.Context, stmtID int64, query string, args []driver.NamedValue) {
+	if ce := l.zapLogger.Check(l.opts.ExecLevel, "STMT Exec"); ce != nil {
+		ce.Write(
+			zap.Int64("stmt_id", stmtID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			namedValuesField(l.opts.Limits.TruncateArgs(args)),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) StmtQuery(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.Value) {
+	if ce := l.zapLogger.Check(l.opts.QueryLevel, "STMT Query"); ce != nil {
+		ce.Write(
+			zap.Int64("stmt_id", stmtID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			valuesField(l.opts.Limits.TruncateValues(args)),
+			zap.Int64("rows_id", rowsID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) StmtQueryContext(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.NamedValue) {
+	if ce := l.zapLogger.Check(l.opts.QueryLevel, "STMT Query"); ce != nil {
+		ce.Write(
+			zap.Int64("stmt_id", stmtID),
+			zap.String("query", l.opts.Limits.TruncateQuery(query)),
+			namedValuesField(l.opts.Limits.TruncateArgs(args)),
+			zap.Int64("rows_id", rowsID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) StmtClose(ctx context.Context, stmtID int64) {
+	if ce := l.zapLogger.Check(l.opts.CloseLevel, "STMT Close"); ce != nil {
+		ce.Write(
+			zap.Int64("stmt_id", stmtID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) RowsClose(ctx context.Context, rowsID int64) {
+	if ce := l.zapLogger.Check(l.opts.CloseLevel, "ROWS Close"); ce != nil {
+		ce.Write(
+			zap.Int64("rows_id", rowsID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) TxCommit(ctx context.Context, txID int64) {
+	if ce := l.zapLogger.Check(l.opts.TxLevel, "TX Commit"); ce != nil {
+		ce.Write(
+			zap.Int64("tx_id", txID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) TxRollback(ctx context.Context, txID int64) {
+	if ce := l.zapLogger.Check(l.opts.TxLevel, "TX Rollback"); ce != nil {
+		ce.Write(
+			zap.Int64("tx_id", txID),
+			duration(ctx),
+		)
+	}
+}
+
+func (l SQLLogger) OperationError(ctx context.Context, ev sqllogger.Event) {
+	ce := l.zapLogger.Check(l.opts.ErrorLevel, "SQL Error")
+	if ce == nil {
+		return
+	}
+	fields := []zap.Field{zap.String("op", string(ev.Op))}
+	if ev.ConnID != 0 {
+		fields = append(fields, zap.Int64("conn_id", ev.ConnID))
+	}
+	if ev.StmtID != 0 {
+		fields = append(fields, zap.Int64("stmt_id", ev.StmtID))
+	}
+	if ev.RowsID != 0 {
+		fields = append(fields, zap.Int64("rows_id", ev.RowsID))
+	}
+	if ev.TxID != 0 {
+		fields = append(fields, zap.Int64("tx_id", ev.TxID))
+	}
+	if ev.Query != "" {
+		fields = append(fields,
+			zap.String("query", l.opts.Limits.TruncateQuery(ev.Query)),
+			namedValuesField(l.opts.Limits.TruncateArgs(ev.NamedValues())),
+		)
+	}
+	fields = append(fields, zap.Error(ev.Err), duration(ctx))
+	ce.Write(fields...)
+}
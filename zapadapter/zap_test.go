@@ -0,0 +1,86 @@
+package zapadapter_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/networkteam/go-sqllogger"
+	"github.com/networkteam/go-sqllogger/zapadapter"
+)
+
+func TestNewSQLLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+
+	ctx := context.Background()
+	start := time.Now()
+	timedCtx := sqllogger.WithTiming(ctx, sqllogger.Timing{Start: start, End: start.Add(1500 * time.Microsecond)})
+
+	sqlLogger := zapadapter.NewSQLLogger(logger)
+	sqlLogger.Connect(ctx, 42)
+	sqlLogger.ConnBegin(ctx, 42, 43, driver.TxOptions{})
+	sqlLogger.ConnQueryContext(timedCtx, 42, 44, "SELECT 1", nil)
+	sqlLogger.ConnExecContext(ctx, 42, "UPDATE t SET a = $1 WHERE c = :c", []driver.NamedValue{
+		{Ordinal: 1, Value: int64(1)},
+		{Ordinal: 2, Name: "c", Value: "x"},
+	})
+	sqlLogger.TxCommit(ctx, 43)
+	sqlLogger.ConnClose(ctx, 42)
+
+	entries := logs.AllUntimed()
+	expectedMessages := []string{"CONN Begin", "CONN Query", "CONN Exec", "TX Commit"}
+	if len(entries) != len(expectedMessages) {
+		t.Fatalf("expected %d log entries, but got %d: %+v", len(expectedMessages), len(entries), entries)
+	}
+	for i, entry := range entries {
+		if entry.Message != expectedMessages[i] {
+			t.Errorf("expected log entry %d to be %q, but got %q", i, expectedMessages[i], entry.Message)
+		}
+	}
+
+	fields := entries[1].ContextMap()
+	if fields["conn_id"] != int64(42) || fields["rows_id"] != int64(44) || fields["query"] != "SELECT 1" {
+		t.Errorf("unexpected fields of query entry: %+v", fields)
+	}
+	if fields["duration"] != 1500*time.Microsecond {
+		t.Errorf("expected duration field of query entry to be 1.5ms, got %v", fields["duration"])
+	}
+	execArgs := fmt.Sprint(entries[2].ContextMap()["args"])
+	if execArgs != "[1 map[name:c value:x]]" {
+		t.Errorf("unexpected args of exec entry: %s", execArgs)
+	}
+	if _, ok := entries[0].ContextMap()["duration"]; ok {
+		t.Errorf("expected no duration field without timing")
+	}
+}
+
+func BenchmarkSQLLogger_ConnQueryContext_Disabled(b *testing.B) {
+	sqlLogger := zapadapter.NewSQLLogger(zap.NewNop())
+	ctx := context.Background()
+	args := []driver.NamedValue{{Ordinal: 1, Value: int64(1)}}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sqlLogger.ConnQueryContext(ctx, 1, 2, "SELECT 1", args)
+	}
+}
+
+func BenchmarkSQLLogger_ConnQueryContext_Enabled(b *testing.B) {
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(io.Discard), zapcore.InfoLevel)
+	sqlLogger := zapadapter.NewSQLLogger(zap.New(core))
+	ctx := context.Background()
+	args := []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: "active"}}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sqlLogger.ConnQueryContext(ctx, 1, 2, "SELECT * FROM users WHERE id = $1 AND status = $2", args)
+	}
+}
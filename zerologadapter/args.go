@@ -0,0 +1,84 @@
+package zerologadapter
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// values encodes arguments without reflection for the common driver.Value types
+type values []driver.Value
+
+func (a values) MarshalZerologArray(arr *zerolog.Array) {
+	for _, v := range a {
+		appendValue(arr, v)
+	}
+}
+
+// namedValues encodes arguments like values, named arguments are encoded as an object with name and value
+type namedValues []driver.NamedValue
+
+func (a namedValues) MarshalZerologArray(arr *zerolog.Array) {
+	for _, nv := range a {
+		if nv.Name != "" {
+			arr.Dict(addValue(zerolog.Dict().Str("name", nv.Name), nv.Value))
+			continue
+		}
+		appendValue(arr, nv.Value)
+	}
+}
+
+func addValue(e *zerolog.Event, v driver.Value) *zerolog.Event {
+	switch v := v.(type) {
+	case int64:
+		return e.Int64("value", v)
+	case float64:
+		return e.Float64("value", v)
+	case bool:
+		return e.Bool("value", v)
+	case string:
+		return e.Str("value", v)
+	case []byte:
+		return e.Bytes("value", v)
+	case time.Time:
+		return e.Time("value", v)
+	default:
+		return e.Interface("value", v)
+	}
+}
+
+func appendValue(arr *zerolog.Array, v driver.Value) {
+	switch v := v.(type) {
+	case int64:
+		arr.Int64(v)
+	case float64:
+		arr.Float64(v)
+	case bool:
+		arr.Bool(v)
+	case string:
+		arr.Str(v)
+	case []byte:
+		arr.Bytes(v)
+	case time.Time:
+		arr.Time(v)
+	default:
+		arr.Interface(v)
+	}
+}
+
+// appendValues adds the arguments as an array, nil arguments are logged as null
+func appendValues(e *zerolog.Event, args []driver.Value) *zerolog.Event {
+	if args == nil {
+		return e.Interface("args", nil)
+	}
+	return e.Array("args", values(args))
+}
+
+// appendNamedValues adds the arguments as an array, nil arguments are logged as null
+func appendNamedValues(e *zerolog.Event, args []driver.NamedValue) *zerolog.Event {
+	if args == nil {
+		return e.Interface("args", nil)
+	}
+	return e.Array("args", namedValues(args))
+}
@@ -0,0 +1,15 @@
+module github.com/networkteam/go-sqllogger/zerologadapter
+
+go 1.23.0
+
+require (
+	github.com/networkteam/go-sqllogger v0.2.0
+	github.com/rs/zerolog v1.34.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+)
+
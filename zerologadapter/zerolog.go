@@ -0,0 +1,305 @@
+// Package zerologadapter provides a SQLLogger implementation for github.com/rs/zerolog.
+package zerologadapter
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/rs/zerolog"
+
+	"github.com/networkteam/go-sqllogger"
+)
+
+func NewSQLLogger(l zerolog.Logger, opts ...Opts) *SQLLogger {
+	var o Opts
+	switch len(opts) {
+	case 0:
+		o = DefaultOpts()
+	case 1:
+		o = opts[0]
+	default:
+		panic("expected zero or one opts")
+	}
+	return &SQLLogger{
+		zerologLogger: l,
+		opts:          o,
+	}
+}
+
+// SQLLogger logs to a zerolog.Logger
+//
+// Fields are only added with the typed methods of zerolog.Event if the level is enabled, so disabled operations do not allocate.
+type SQLLogger struct {
+	zerologLogger zerolog.Logger
+
+	opts Opts
+}
+
+var _ sqllogger.SQLLogger = SQLLogger{}
+var _ sqllogger.SQLErrorLogger = SQLLogger{}
+
+type Opts struct {
+	ConnectLevel zerolog.Level
+	PrepareLevel zerolog.Level
+	QueryLevel   zerolog.Level
+	ExecLevel    zerolog.Level
+	CloseLevel   zerolog.Level
+	TxLevel      zerolog.Level
+	ErrorLevel   zerolog.Level
+
+	// Limits truncates long queries and large argument values
+	Limits sqllogger.Limits
+}
+
+func DefaultOpts() Opts {
+	return Opts{
+		ConnectLevel: zerolog.DebugLevel,
+		PrepareLevel: zerolog.DebugLevel,
+		QueryLevel:   zerolog.InfoLevel,
+		ExecLevel:    zerolog.InfoLevel,
+		CloseLevel:   zerolog.DebugLevel,
+		TxLevel:      zerolog.InfoLevel,
+		ErrorLevel:   zerolog.ErrorLevel,
+	}
+}
+
+// event starts a new event with the context and the duration of the operation, if available
+//
+// The returned event is nil if the level is disabled.
+func (l SQLLogger) event(ctx context.Context, level zerolog.Level) *zerolog.Event {
+	e := l.zerologLogger.WithLevel(level)
+	if e == nil {
+		return nil
+	}
+	e = e.Ctx(ctx)
+	if timing, ok := sqllogger.GetTiming(ctx); ok {
+		e = e.Dur("duration", timing.Duration())
+	}
+	return e
+}
+
+func (l SQLLogger) Connect(ctx context.Context, connID int64) {
+	e := l.event(ctx, l.opts.ConnectLevel)
+	if e == nil {
+		return
+	}
+	e.
+		Int64("conn_id", connID).
+		Msg("DB Connect")
+}
+
+func (l SQLLogger) ConnBegin(ctx context.Context, connID, txID int64, opts driver.TxOptions) {
+	e := l.event(ctx, l.opts.TxLevel)
+	if e == nil {
+		return
+	}
+	e.
+		Int64("conn_id", connID).
+		Int64("tx_id", txID).
+		Msg("CONN Begin")
+}
+
+func (l SQLLogger) ConnPrepare(ctx context.Context, connID, stmtID int64, query string) {
+	e := l.event(ctx, l.opts.PrepareLevel)
+	if e == nil {
+		return
+	}
+	e.
+		Int64("conn_id", connID).
+		Str("query", l.opts.Limits.TruncateQuery(query)).
+		Int64("stmt_id", stmtID).
+		Msg("CONN Prepare")
+}
+
+func (l SQLLogger) ConnPrepareContext(ctx context.Context, connID int64, stmtID int64, query string) {
+	e := l.event(ctx, l.opts.PrepareLevel)
+	if e == nil {
+		return
+	}
+	e.
+		Int64("conn_id", connID).
+		Str("query", l.opts.Limits.TruncateQuery(query)).
+		Int64("stmt_id", stmtID).
+		Msg("CONN Prepare")
+}
+
+func (l SQLLogger) ConnQuery(ctx context.Context, connID, rowsID int64, query string, args []driver.Value) {
+	e := l.event(ctx, l.opts.QueryLevel)
+	if e == nil {
+		return
+	}
+	e = e.
+		Int64("conn_id", connID).
+		Str("query", l.opts.Limits.TruncateQuery(query))
+	e = appendValues(e, l.opts.Limits.TruncateValues(args))
+	e.
+		Int64("rows_id", rowsID).
+		Msg("CONN Query")
+}
+
+func (l SQLLogger) ConnQueryContext(ctx context.Context, connID int64, rowsID int64, query string, args []driver.NamedValue) {
+	e := l.event(ctx, l.opts.QueryLevel)
+	if e == nil {
+		return
+	}
+	e = e.
+		Int64("conn_id", connID).
+		Str("query", l.opts.Limits.TruncateQuery(query))
+	e = appendNamedValues(e, l.opts.Limits.TruncateArgs(args))
+	e.
+		Int64("rows_id", rowsID).
+		Msg("CONN Query")
+}
+
+func (l SQLLogger) ConnExec(ctx context.Context, connID int64, query string, args []driver.Value) {
+	e := l.event(ctx, l.opts.ExecLevel)
+	if e == nil {
+		return
+	}
+	e = e.
+		Int64("conn_id", connID).
+		Str("query", l.opts.Limits.TruncateQuery(query))
+	e = appendValues(e, l.opts.Limits.TruncateValues(args))
+	e.Msg("CONN Exec")
+}
+
+func (l SQLLogger) ConnExecContext(ctx context.Context, connID int64, query string, args []driver.NamedValue) {
+	e := l.event(ctx, l.opts.ExecLevel)
+	if e == nil {
+		return
+	}
+	e = e.
+		Int64("conn_id", connID).
+		Str("query", l.opts.Limits.TruncateQuery(query))
+	e = appendNamedValues(e, l.opts.Limits.TruncateArgs(args))
+	e.Msg("CONN Exec")
+}
+
+func (l SQLLogger) ConnClose(ctx context.Context, connID int64) {
+	e := l.event(ctx, l.opts.CloseLevel)
+	if e == nil {
+		return
+	}
+	e.
+		Int64("conn_id", connID).
+		Msg("CONN Close")
+}
+
+func (l SQLLogger) StmtExec(ctx context.Context, stmtID int64, query string, args []driver.Value) {
+	e := l.event(ctx, l.opts.ExecLevel)
+	if e == nil {
+		return
+	}
+	e = e.
+		Int64("stmt_id", stmtID).
+		Str("query", l.opts.Limits.TruncateQuery(query))
+	e = appendValues(e, l.opts.Limits.TruncateValues(args))
+	e.Msg("STMT Exec")
+}
+
+func (l SQLLogger) StmtExecContext(ctx context.Context, stmtID int64, query string, args []driver.NamedValue) {
+	e := l.event(ctx, l.opts.ExecLevel)
+	if e == nil {
+		return
+	}
+	e = e.
+		Int64("stmt_id", stmtID).
+		Str("query", l.opts.Limits.TruncateQuery(query))
+	e = appendNamedValues(e, l.opts.Limits.TruncateArgs(args))
+	e.Msg("STMT Exec")
+}
+
+func (l SQLLogger) StmtQuery(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.Value) {
+	e := l.event(ctx, l.opts.QueryLevel)
+	if e == nil {
+		return
+	}
+	e = e.
+		Int64("stmt_id", stmtID).
+		Str("query", l.opts.Limits.TruncateQuery(query))
+	e = appendValues(e, l.opts.Limits.TruncateValues(args))
+	e.
+		Int64("rows_id", rowsID).
+		Msg("STMT Query")
+}
+
+func (l SQLLogger) StmtQueryContext(ctx context.Context, stmtID int64, rowsID int64, query string, args []driver.NamedValue) {
+	e := l.event(ctx, l.opts.QueryLevel)
+	if e == nil {
+		return
+	}
+	e = e.
+		Int64("stmt_id", stmtID).
+		Str("query", l.opts.Limits.TruncateQuery(query))
+	e = appendNamedValues(e, l.opts.Limits.TruncateArgs(args))
+	e.
+		Int64("rows_id", rowsID).
+		Msg("STMT Query")
+}
+
+func (l SQLLogger) StmtClose(ctx context.Context, stmtID int64) {
+	e := l.event(ctx, l.opts.CloseLevel)
+	if e == nil {
+		return
+	}
+	e.
+		Int64("stmt_id", stmtID).
+		Msg("STMT Close")
+}
+
+func (l SQLLogger) RowsClose(ctx context.Context, rowsID int64) {
+	e := l.event(ctx, l.opts.CloseLevel)
+	if e == nil {
+		return
+	}
+	e.
+		Int64("rows_id", rowsID).
+		Msg("ROWS Close")
+}
+
+func (l SQLLogger) TxCommit(ctx context.Context, txID int64) {
+	e := l.event(ctx, l.opts.TxLevel)
+	if e == nil {
+		return
+	}
+	e.
+		Int64("tx_id", txID).
+		Msg("TX Commit")
+}
+
+func (l SQLLogger) TxRollback(ctx context.Context, txID int64) {
+	e := l.event(ctx, l.opts.TxLevel)
+	if e == nil {
+		return
+	}
+	e.
+		Int64("tx_id", txID).
+		Msg("TX Rollback")
+}
+
+func (l SQLLogger) OperationError(ctx context.Context, ev sqllogger.Event) {
+	e := l.event(ctx, l.opts.ErrorLevel)
+	if e == nil {
+		return
+	}
+	e = e.Str("op", string(ev.Op))
+	if ev.ConnID != 0 {
+		e = e.Int64("conn_id", ev.ConnID)
+	}
+	if ev.StmtID != 0 {
+		e = e.Int64("stmt_id", ev.StmtID)
+	}
+	if ev.RowsID != 0 {
+		e = e.Int64("rows_id", ev.RowsID)
+	}
+	if ev.TxID != 0 {
+		e = e.Int64("tx_id", ev.TxID)
+	}
+	if ev.Query != "" {
+		e = e.Str("query", l.opts.Limits.TruncateQuery(ev.Query))
+		e = appendNamedValues(e, l.opts.Limits.TruncateArgs(ev.NamedValues()))
+	}
+	e.
+		Err(ev.Err).
+		Msg("SQL Error")
+}
@@ -0,0 +1,76 @@
+package zerologadapter_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/networkteam/go-sqllogger"
+	"github.com/networkteam/go-sqllogger/zerologadapter"
+)
+
+func TestNewSQLLogger(t *testing.T) {
+	var out bytes.Buffer
+
+	logger := zerolog.New(&out).Level(zerolog.InfoLevel)
+
+	ctx := context.Background()
+	start := time.Now()
+	timedCtx := sqllogger.WithTiming(ctx, sqllogger.Timing{Start: start, End: start.Add(1500 * time.Microsecond)})
+
+	sqlLogger := zerologadapter.NewSQLLogger(logger)
+	sqlLogger.Connect(ctx, 42)
+	sqlLogger.ConnBegin(ctx, 42, 43, driver.TxOptions{})
+	sqlLogger.ConnQueryContext(timedCtx, 42, 44, "SELECT 1", nil)
+	sqlLogger.ConnExecContext(ctx, 42, "UPDATE t SET a = $1, b = $2 WHERE c = :c", []driver.NamedValue{
+		{Ordinal: 1, Value: int64(1)},
+		{Ordinal: 2, Value: "x"},
+		{Ordinal: 3, Name: "c", Value: true},
+	})
+	sqlLogger.TxCommit(ctx, 43)
+	sqlLogger.ConnClose(ctx, 42)
+
+	actualLog := out.String()
+	expectedLogLines := []string{
+		`{"level":"info","conn_id":42,"tx_id":43,"message":"CONN Begin"}`,
+		`{"level":"info","duration":1.5,"conn_id":42,"query":"SELECT 1","args":null,"rows_id":44,"message":"CONN Query"}`,
+		`{"level":"info","conn_id":42,"query":"UPDATE t SET a = $1, b = $2 WHERE c = :c","args":[1,"x",{"name":"c","value":true}],"message":"CONN Exec"}`,
+		`{"level":"info","tx_id":43,"message":"TX Commit"}`,
+	}
+	for i, logLine := range expectedLogLines {
+		if !strings.Contains(actualLog, logLine) {
+			t.Fatalf("expected log line %d:\n%s\n, but got:\n%s\n", i, logLine, actualLog)
+		}
+	}
+	if strings.Contains(actualLog, "DB Connect") {
+		t.Errorf("expected connect not to be logged at info level, but got:\n%s\n", actualLog)
+	}
+}
+
+func BenchmarkSQLLogger_ConnQueryContext_Disabled(b *testing.B) {
+	sqlLogger := zerologadapter.NewSQLLogger(zerolog.Nop())
+	ctx := context.Background()
+	args := []driver.NamedValue{{Ordinal: 1, Value: int64(1)}}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sqlLogger.ConnQueryContext(ctx, 1, 2, "SELECT 1", args)
+	}
+}
+
+func BenchmarkSQLLogger_ConnQueryContext_Enabled(b *testing.B) {
+	sqlLogger := zerologadapter.NewSQLLogger(zerolog.New(io.Discard))
+	ctx := context.Background()
+	args := []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: "active"}}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sqlLogger.ConnQueryContext(ctx, 1, 2, "SELECT * FROM users WHERE id = $1 AND status = $2", args)
+	}
+}